@@ -94,7 +94,7 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Banner = (*v1beta1.Banner)(src.Spec.Banner)
 
 	// Status conversion
-	dst.Status = v1beta1.ArgoCDStatus(src.Status)
+	dst.Status = ConvertAlphaToBetaStatus(src.Status)
 
 	return nil
 }
@@ -161,7 +161,7 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.Banner = (*Banner)(src.Spec.Banner)
 
 	// Status conversion
-	dst.Status = ArgoCDStatus(src.Status)
+	dst.Status = ConvertBetaToAlphaStatus(src.Status)
 
 	return nil
 }
@@ -426,6 +426,22 @@ func ConvertAlphaToBetaResourceHealthChecks(src []ResourceHealthCheck) []v1beta1
 	return dst
 }
 
+func ConvertAlphaToBetaStatus(src ArgoCDStatus) v1beta1.ArgoCDStatus {
+	return v1beta1.ArgoCDStatus{
+		ApplicationController:    src.ApplicationController,
+		ApplicationSetController: src.ApplicationSetController,
+		SSO:                      src.SSO,
+		NotificationsController:  src.NotificationsController,
+		Phase:                    src.Phase,
+		Redis:                    src.Redis,
+		Repo:                     src.Repo,
+		Server:                   src.Server,
+		RepoTLSChecksum:          src.RepoTLSChecksum,
+		RedisTLSChecksum:         src.RedisTLSChecksum,
+		Host:                     src.Host,
+	}
+}
+
 // Conversion funcs for v1beta1 to v1alpha1.
 func ConvertBetaToAlphaController(src *v1beta1.ArgoCDApplicationControllerSpec) *ArgoCDApplicationControllerSpec {
 	var dst *ArgoCDApplicationControllerSpec
@@ -684,3 +700,19 @@ func ConvertBetaToAlphaRepo(src *v1beta1.ArgoCDRepoSpec) *ArgoCDRepoSpec {
 	}
 	return dst
 }
+
+func ConvertBetaToAlphaStatus(src v1beta1.ArgoCDStatus) ArgoCDStatus {
+	return ArgoCDStatus{
+		ApplicationController:    src.ApplicationController,
+		ApplicationSetController: src.ApplicationSetController,
+		SSO:                      src.SSO,
+		NotificationsController:  src.NotificationsController,
+		Phase:                    src.Phase,
+		Redis:                    src.Redis,
+		Repo:                     src.Repo,
+		Server:                   src.Server,
+		RepoTLSChecksum:          src.RepoTLSChecksum,
+		RedisTLSChecksum:         src.RedisTLSChecksum,
+		Host:                     src.Host,
+	}
+}
@@ -111,6 +111,20 @@ type ArgoCDApplicationControllerSpec struct {
 
 	// Enabled is the flag to enable the Application Controller during ArgoCD installation. (optional, default `true`)
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// ServiceAccountAnnotations defines the annotations added to the Application Controller service account, e.g. for cloud workload identity (optional)
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+
+	// MetricsServicePort is the port of the Service exposing the metrics of the Application Controller. (optional, default `8082`)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	MetricsServicePort int32 `json:"metricsServicePort,omitempty"`
+
+	// MetricsServicePortName is the name of the port of the Service exposing the metrics of the Application Controller. (optional, default `metrics`)
+	MetricsServicePortName string `json:"metricsServicePortName,omitempty"`
+
+	// MetricsServiceAnnotations defines additional annotations added to the Service exposing the metrics of the Application Controller (optional)
+	MetricsServiceAnnotations map[string]string `json:"metricsServiceAnnotations,omitempty"`
 }
 
 func (a *ArgoCDApplicationControllerSpec) IsEnabled() bool {
@@ -147,6 +161,10 @@ type ArgoCDApplicationSet struct {
 	// Env lets you specify environment for applicationSet controller pods
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// Command overrides the default entrypoint of the ApplicationSet controller container. The command line arguments
+	// computed by the operator, including ExtraCommandArgs, are appended to it. (optional)
+	Command []string `json:"command,omitempty"`
+
 	// ExtraCommandArgs allows users to pass command line arguments to ApplicationSet controller.
 	// They get added to default command line arguments provided by the operator.
 	// Please note that the command line arguments provided as part of ExtraCommandArgs
@@ -159,6 +177,10 @@ type ArgoCDApplicationSet struct {
 	// Version is the Argo CD ApplicationSet image tag. (optional)
 	Version string `json:"version,omitempty"`
 
+	// ImagePullPolicy is the image pull policy for the ApplicationSet controller container. Defaults to Always if not set. (optional)
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
 	// Resources defines the Compute Resources required by the container for ApplicationSet.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
@@ -170,6 +192,14 @@ type ArgoCDApplicationSet struct {
 	// SCMRootCAConfigMap is the name of the config map that stores the Gitlab SCM Provider's TLS certificate which will be mounted on the ApplicationSet Controller (optional).
 	SCMRootCAConfigMap string `json:"scmRootCAConfigMap,omitempty"`
 
+	// SSHKnownHostsConfigMap is the name of the config map holding the SSH known hosts mounted on the ApplicationSet
+	// controller, in place of the argocd-ssh-known-hosts-cm config map. (optional)
+	SSHKnownHostsConfigMap string `json:"sshKnownHostsConfigMap,omitempty"`
+
+	// TrustedCAConfigMap is the name of a config map holding PEM encoded CA certificates trusted by the ApplicationSet
+	// controller for all HTTPS calls, in addition to the system CA certificates. (optional)
+	TrustedCAConfigMap string `json:"trustedCAConfigMap,omitempty"`
+
 	// Enabled is the flag to enable the Application Set Controller during ArgoCD installation. (optional, default `true`)
 	Enabled *bool `json:"enabled,omitempty"`
 
@@ -178,12 +208,123 @@ type ArgoCDApplicationSet struct {
 
 	// SCMProviders defines the list of allowed custom SCM provider API URLs
 	SCMProviders []string `json:"scmProviders,omitempty"`
+
+	// DisableSCMProviders disables the SCM Provider and Pull Request generators when true, and keeps them enabled when
+	// false. When not set, they are disabled if SourceNamespaces is set without an SCMProviders allow list. (optional)
+	DisableSCMProviders *bool `json:"disableSCMProviders,omitempty"`
+
+	// SidecarContainers defines the list of sidecar containers for the ApplicationSet controller deployment
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
+
+	// Replicas defines the number of replicas for the ApplicationSet controller. Leader election is enabled when more than one replica is requested. Ignored when Autoscale is enabled. (optional)
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// NetworkPolicy restricts the ingress traffic of the ApplicationSet controller pods to the webhook port, and to the
+	// metrics port from Prometheus pods, when true. (optional)
+	NetworkPolicy bool `json:"networkPolicy,omitempty"`
+
+	// Autoscale defines a HorizontalPodAutoscaler for the ApplicationSet controller. Leader election is enabled while it is. (optional)
+	Autoscale *ArgoCDApplicationSetAutoscaleSpec `json:"autoscale,omitempty"`
+
+	// Policy defines how the ApplicationSet controller syncs the generated Applications, one of sync, create-only,
+	// create-update and create-delete. Defaults to the controller default of sync. (optional)
+	Policy string `json:"policy,omitempty"`
+
+	// PreservedAnnotations defines the list of annotations the ApplicationSet controller preserves on the generated
+	// Applications when they are updated. (optional)
+	PreservedAnnotations []string `json:"preservedAnnotations,omitempty"`
+
+	// GitTimeout is the timeout of the repo server calls made by the ApplicationSet controller, e.g. for the git
+	// generators resolving large repositories. Rounded up to whole seconds. Defaults to the controller default of 60s. (optional)
+	GitTimeout *metav1.Duration `json:"gitTimeout,omitempty"`
+
+	// ReadinessProbe defines the timings of the readiness probe of the ApplicationSet controller container. (optional)
+	ReadinessProbe *ArgoCDProbeSpec `json:"readinessProbe,omitempty"`
+
+	// LivenessProbe defines the timings of the liveness probe of the ApplicationSet controller container. (optional)
+	LivenessProbe *ArgoCDProbeSpec `json:"livenessProbe,omitempty"`
+
+	// ServiceAccountAnnotations defines the annotations added to the ApplicationSet controller service account, e.g. for cloud workload identity (optional)
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+
+	// ServiceAccountToken replaces the automounted service account token of the ApplicationSet controller with a
+	// projected, bound token. (optional)
+	ServiceAccountToken *ArgoCDServiceAccountTokenSpec `json:"serviceAccountToken,omitempty"`
+
+	// Volumes adds volumes to the ApplicationSet controller deployment. A volume with the same name as one of the
+	// operator managed volumes replaces it. (optional)
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts adds volumeMounts to the ApplicationSet controller container. A volumeMount with the same mount path
+	// as one of the operator managed volumeMounts replaces it. (optional)
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Labels is the map of labels added to the ApplicationSet controller Service, ServiceAccount, Role and
+	// RoleBinding. Labels set by the operator take precedence. (optional)
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations is the map of annotations added to the ApplicationSet controller Service, ServiceAccount, Role and
+	// RoleBinding. (optional)
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 func (a *ArgoCDApplicationSet) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
 
+// ArgoCDApplicationSetAutoscaleSpec defines the desired state for autoscaling the ApplicationSet controller.
+type ArgoCDApplicationSetAutoscaleSpec struct {
+	// Enabled will toggle the HorizontalPodAutoscaler of the ApplicationSet controller.
+	Enabled bool `json:"enabled"`
+
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1. (optional)
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas. Defaults to 3. (optional)
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// TargetCPUUtilizationPercentage is the target average CPU utilization of the replicas. Defaults to 50. (optional)
+	// +kubebuilder:validation:Minimum=1
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+}
+
+// ArgoCDServiceAccountTokenSpec defines a projected service account token volume, mounted at the path of the
+// automounted token.
+type ArgoCDServiceAccountTokenSpec struct {
+	// Enabled will toggle the projected service account token volume.
+	Enabled bool `json:"enabled"`
+
+	// Audience is the intended audience of the token. Defaults to the audience of the API server. (optional)
+	Audience string `json:"audience,omitempty"`
+
+	// ExpirationSeconds is the requested lifetime of the token, which is rotated by the kubelet before it expires.
+	// Defaults to 3607. (optional)
+	// +kubebuilder:validation:Minimum=600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// ArgoCDProbeSpec defines the timings of a container probe. Unset fields use the operator defaults.
+type ArgoCDProbeSpec struct {
+	// InitialDelaySeconds is the number of seconds after the container has started before the probe is initiated.
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds defines how often (in seconds) to perform the probe.
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures for the probe to be considered failed.
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
 // ArgoCDCASpec defines the CA options for ArgCD.
 type ArgoCDCASpec struct {
 	// ConfigMapName is the name of the ConfigMap containing the CA Certificate.
@@ -275,8 +416,24 @@ type ArgoCDHASpec struct {
 
 	// Resources defines the Compute Resources required by the container for HA.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// RedisAntiAffinity defines whether the Redis HA pods are required, or only preferred, to run on distinct nodes. (optional, default `required`)
+	// +kubebuilder:validation:Enum=required;preferred
+	RedisAntiAffinity string `json:"redisAntiAffinity,omitempty"`
+
+	// RedisAffinity overrides the affinity of the Redis HA pods, taking precedence over RedisAntiAffinity.
+	RedisAffinity *corev1.Affinity `json:"redisAffinity,omitempty"`
 }
 
+const (
+	// ArgoCDRedisAntiAffinityRequired requires the Redis HA pods to run on distinct nodes.
+	ArgoCDRedisAntiAffinityRequired = "required"
+
+	// ArgoCDRedisAntiAffinityPreferred prefers the Redis HA pods to run on distinct nodes, but still schedules them
+	// when there are fewer nodes than replicas.
+	ArgoCDRedisAntiAffinityPreferred = "preferred"
+)
+
 // ArgoCDImportSpec defines the desired state for the ArgoCD import/restore process.
 type ArgoCDImportSpec struct {
 	// Name of an ArgoCDExport from which to import data.
@@ -362,6 +519,9 @@ type ArgoCDNotifications struct {
 
 	// LogLevel describes the log level that should be used by the argocd-notifications. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
+
+	// LogFormat describes the log format that should be used by the argocd-notifications. Defaults to ArgoCDDefaultLogFormat if not configured. Valid options are text or json.
+	LogFormat string `json:"logFormat,omitempty"`
 }
 
 // ArgoCDPrometheusSpec defines the desired state for the Prometheus component.
@@ -439,12 +599,50 @@ type ArgoCDRedisSpec struct {
 
 	// Remote specifies the remote URL of the Redis container. (optional, by default, a local instance managed by the operator is used.)
 	Remote *string `json:"remote,omitempty"`
+
+	// Persistence defines the persistence options of Redis when not running in HA mode. (optional, by default, persistence is disabled)
+	Persistence *ArgoCDRedisPersistenceSpec `json:"persistence,omitempty"`
+
+	// MaxMemory defines the memory limit of Redis when not running in HA mode, in the format accepted by the --maxmemory argument of Redis, e.g. "200mb". (optional, by default, derived from the memory limit of the container)
+	MaxMemory string `json:"maxMemory,omitempty"`
+
+	// MaxMemoryPolicy defines the key eviction policy of Redis once MaxMemory is reached, e.g. "allkeys-lfu". (optional, default `allkeys-lru`)
+	MaxMemoryPolicy string `json:"maxMemoryPolicy,omitempty"`
+
+	// Monitoring defines the options of the Redis metrics exporter. (optional, by default, the metrics of Redis are not exported)
+	Monitoring *ArgoCDRedisMonitoringSpec `json:"monitoring,omitempty"`
+}
+
+// ArgoCDRedisMonitoringSpec defines the options of the Redis metrics exporter.
+type ArgoCDRedisMonitoringSpec struct {
+	// Enabled is the flag to add a metrics exporter sidecar to the Redis pods, and a ServiceMonitor for it when the Prometheus API is available.
+	Enabled bool `json:"enabled"`
+
+	// Image is the Redis metrics exporter container image.
+	Image string `json:"image,omitempty"`
+
+	// Version is the Redis metrics exporter container image tag.
+	Version string `json:"version,omitempty"`
+}
+
+// ArgoCDRedisPersistenceSpec defines the persistence options of Redis.
+type ArgoCDRedisPersistenceSpec struct {
+	// Save defines the RDB snapshot points, as "<seconds> <changes>" pairs separated by spaces, e.g. "900 1 300 10". (optional, by default, no snapshots are taken)
+	Save string `json:"save,omitempty"`
+
+	// AppendOnly enables the append only file (AOF) persistence. (optional, default `false`)
+	AppendOnly bool `json:"appendOnly,omitempty"`
 }
 
 func (a *ArgoCDRedisSpec) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
 
+// IsRemote returns true if a remote Redis is used instead of the one managed by the operator.
+func (a *ArgoCDRedisSpec) IsRemote() bool {
+	return a.Remote != nil && *a.Remote != ""
+}
+
 // ArgoCDRepoSpec defines the desired state for the Argo CD repo server component.
 type ArgoCDRepoSpec struct {
 
@@ -606,6 +804,9 @@ type ArgoCDServerSpec struct {
 
 	// Enabled is the flag to enable ArgoCD Server during ArgoCD installation. (optional, default `true`)
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// ServiceAccountAnnotations defines the annotations added to the Argo CD Server service account, e.g. for cloud workload identity (optional)
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
 }
 
 func (a *ArgoCDServerSpec) IsEnabled() bool {
@@ -780,6 +981,15 @@ type ArgoCDSpec struct {
 	// Monitoring defines whether workload status monitoring configuration for this instance.
 	Monitoring ArgoCDMonitoringSpec `json:"monitoring,omitempty"`
 
+	// DNSPolicy defines the DNS policy of the ApplicationSet controller and Redis pods. Defaults to the cluster default if not set. (optional)
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig defines the DNS parameters of the ApplicationSet controller and Redis pods, in addition to those generated from DNSPolicy. (optional)
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// HostAliases defines the entries added to the /etc/hosts file of the Argo CD workload pods, e.g. to reach internal hosts in air-gapped clusters (optional)
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
 	// NodePlacement defines NodeSelectors and Taints for Argo CD workloads
 	NodePlacement *ArgoCDNodePlacementSpec `json:"nodePlacement,omitempty"`
 
@@ -937,6 +1147,32 @@ type ArgoCDStatus struct {
 
 	// Host is the hostname of the Ingress.
 	Host string `json:"host,omitempty"`
+
+	// ApplicationSetSourceNamespaces records the reconciliation state of each namespace listed in spec.applicationSet.sourceNamespaces.
+	ApplicationSetSourceNamespaces []ArgoCDApplicationSetSourceNamespaceStatus `json:"applicationSetSourceNamespaces,omitempty"`
+}
+
+const (
+	// ApplicationSetSourceNamespaceReconciled indicates the ApplicationSet resources were reconciled in the source namespace.
+	ApplicationSetSourceNamespaceReconciled = "Reconciled"
+
+	// ApplicationSetSourceNamespaceSkipped indicates the source namespace was intentionally not reconciled.
+	ApplicationSetSourceNamespaceSkipped = "Skipped"
+
+	// ApplicationSetSourceNamespaceFailed indicates an error occurred while reconciling the source namespace.
+	ApplicationSetSourceNamespaceFailed = "Failed"
+)
+
+// ArgoCDApplicationSetSourceNamespaceStatus defines the observed state of an ApplicationSet source namespace.
+type ArgoCDApplicationSetSourceNamespaceStatus struct {
+	// Name is the name of the source namespace.
+	Name string `json:"name"`
+
+	// State is the reconciliation state of the source namespace. One of Reconciled, Skipped or Failed.
+	State string `json:"state"`
+
+	// Message is a human readable explanation of the state, e.g. the error that caused a failure.
+	Message string `json:"message,omitempty"`
 }
 
 // Banner defines an additional banner message to be displayed in Argo CD UI
@@ -36,7 +36,7 @@ func (in *ArgoCD) DeepCopyInto(out *ArgoCD) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCD.
@@ -119,6 +119,20 @@ func (in *ArgoCDApplicationControllerSpec) DeepCopyInto(out *ArgoCDApplicationCo
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MetricsServiceAnnotations != nil {
+		in, out := &in.MetricsServiceAnnotations, &out.MetricsServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationControllerSpec.
@@ -141,6 +155,11 @@ func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ExtraCommandArgs != nil {
 		in, out := &in.ExtraCommandArgs, &out.ExtraCommandArgs
 		*out = make([]string, len(*in))
@@ -167,6 +186,88 @@ func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DisableSCMProviders != nil {
+		in, out := &in.DisableSCMProviders, &out.DisableSCMProviders
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PreservedAnnotations != nil {
+		in, out := &in.PreservedAnnotations, &out.PreservedAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SidecarContainers != nil {
+		in, out := &in.SidecarContainers, &out.SidecarContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Autoscale != nil {
+		in, out := &in.Autoscale, &out.Autoscale
+		*out = new(ArgoCDApplicationSetAutoscaleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitTimeout != nil {
+		in, out := &in.GitTimeout, &out.GitTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ArgoCDProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ArgoCDProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(ArgoCDServiceAccountTokenSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSet.
@@ -179,6 +280,46 @@ func (in *ArgoCDApplicationSet) DeepCopy() *ArgoCDApplicationSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDApplicationSetAutoscaleSpec) DeepCopyInto(out *ArgoCDApplicationSetAutoscaleSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSetAutoscaleSpec.
+func (in *ArgoCDApplicationSetAutoscaleSpec) DeepCopy() *ArgoCDApplicationSetAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDApplicationSetAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDApplicationSetSourceNamespaceStatus) DeepCopyInto(out *ArgoCDApplicationSetSourceNamespaceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSetSourceNamespaceStatus.
+func (in *ArgoCDApplicationSetSourceNamespaceStatus) DeepCopy() *ArgoCDApplicationSetSourceNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDApplicationSetSourceNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDCASpec) DeepCopyInto(out *ArgoCDCASpec) {
 	*out = *in
@@ -276,6 +417,11 @@ func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RedisAffinity != nil {
+		in, out := &in.RedisAffinity, &out.RedisAffinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
@@ -475,6 +621,41 @@ func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProbeSpec) DeepCopyInto(out *ArgoCDProbeSpec) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProbeSpec.
+func (in *ArgoCDProbeSpec) DeepCopy() *ArgoCDProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
 	*out = *in
@@ -532,6 +713,36 @@ func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisMonitoringSpec) DeepCopyInto(out *ArgoCDRedisMonitoringSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisMonitoringSpec.
+func (in *ArgoCDRedisMonitoringSpec) DeepCopy() *ArgoCDRedisMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisPersistenceSpec) DeepCopyInto(out *ArgoCDRedisPersistenceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisPersistenceSpec.
+func (in *ArgoCDRedisPersistenceSpec) DeepCopy() *ArgoCDRedisPersistenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisPersistenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
 	*out = *in
@@ -550,6 +761,16 @@ func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(ArgoCDRedisPersistenceSpec)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(ArgoCDRedisMonitoringSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
@@ -792,6 +1013,13 @@ func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerSpec.
@@ -804,6 +1032,26 @@ func (in *ArgoCDServerSpec) DeepCopy() *ArgoCDServerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServiceAccountTokenSpec) DeepCopyInto(out *ArgoCDServiceAccountTokenSpec) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServiceAccountTokenSpec.
+func (in *ArgoCDServiceAccountTokenSpec) DeepCopy() *ArgoCDServiceAccountTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServiceAccountTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 	*out = *in
@@ -834,6 +1082,18 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		copy(*out, *in)
 	}
 	out.Monitoring = in.Monitoring
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]v1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.NodePlacement != nil {
 		in, out := &in.NodePlacement, &out.NodePlacement
 		*out = new(ArgoCDNodePlacementSpec)
@@ -891,6 +1151,11 @@ func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDStatus) DeepCopyInto(out *ArgoCDStatus) {
 	*out = *in
+	if in.ApplicationSetSourceNamespaces != nil {
+		in, out := &in.ApplicationSetSourceNamespaces, &out.ApplicationSetSourceNamespaces
+		*out = make([]ArgoCDApplicationSetSourceNamespaceStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStatus.
@@ -14,6 +14,8 @@
 
 package common
 
+import "time"
+
 const (
 	// ArgoCDApplicationControllerComponent is the name of the application controller control plane component
 	ArgoCDApplicationControllerComponent = "argocd-application-controller"
@@ -45,6 +47,44 @@ const (
 	// ArgoCDApplicationSetControllerComponent is the name of the ApplictionSet controller control plane component
 	ArgoCDApplicationSetControllerComponent = "argocd-applicationset-controller"
 
+	// ArgoCDDefaultClusterDomain is the default DNS domain of the cluster.
+	ArgoCDDefaultClusterDomain = "cluster.local"
+
+	// ArgoCDDefaultReconcileTimeout is the default maximum duration of a single ArgoCD reconcile.
+	ArgoCDDefaultReconcileTimeout = 5 * time.Minute
+
+	// ArgoCDDefaultAppSetSourceNamespacesConcurrency is the default number of ApplicationSet source namespaces reconciled in parallel.
+	ArgoCDDefaultAppSetSourceNamespacesConcurrency = 5
+
+	// ArgoCDDefaultApplicationSetResourceLimitCPU is the default CPU limit when not specified for the ApplicationSet
+	// controller container.
+	ArgoCDDefaultApplicationSetResourceLimitCPU = "1000m"
+
+	// ArgoCDDefaultApplicationSetResourceLimitMemory is the default memory limit when not specified for the
+	// ApplicationSet controller container.
+	ArgoCDDefaultApplicationSetResourceLimitMemory = "512Mi"
+
+	// ArgoCDDefaultApplicationSetResourceRequestCPU is the default CPU requested when not specified for the
+	// ApplicationSet controller container.
+	ArgoCDDefaultApplicationSetResourceRequestCPU = "250m"
+
+	// ArgoCDDefaultApplicationSetResourceRequestMemory is the default memory requested when not specified for the
+	// ApplicationSet controller container.
+	ArgoCDDefaultApplicationSetResourceRequestMemory = "128Mi"
+
+	// ArgoCDDefaultRedisResourceLimitCPU is the default CPU limit when not specified for the Redis containers.
+	ArgoCDDefaultRedisResourceLimitCPU = "500m"
+
+	// ArgoCDDefaultRedisResourceLimitMemory is the default memory limit when not specified for the Redis containers.
+	ArgoCDDefaultRedisResourceLimitMemory = "256Mi"
+
+	// ArgoCDDefaultRedisResourceRequestCPU is the default CPU requested when not specified for the Redis containers.
+	ArgoCDDefaultRedisResourceRequestCPU = "250m"
+
+	// ArgoCDDefaultRedisResourceRequestMemory is the default memory requested when not specified for the Redis
+	// containers.
+	ArgoCDDefaultRedisResourceRequestMemory = "128Mi"
+
 	// ArgoCDOperatorGrafanaComponent is the name of the Grafana control plane component
 	ArgoCDOperatorGrafanaComponent = "argocd-grafana"
 
@@ -177,6 +217,10 @@ const (
 	// ArgoCDDefaultRBACScopes is the default Argo CD RBAC scopes.
 	ArgoCDDefaultRBACScopes = "[groups]"
 
+	// ArgoCDDefaultApplicationControllerMetricsPort is the default port of the Service exposing the metrics of the
+	// Application Controller.
+	ArgoCDDefaultApplicationControllerMetricsPort = int32(8082)
+
 	// ArgoCDDefaultRedisConfigPath is the default Redis configuration directory when not specified.
 	ArgoCDDefaultRedisConfigPath = "/var/lib/redis"
 
@@ -189,9 +233,25 @@ const (
 	// ArgoCDDefaultRedisHAProxyVersion is the default Redis HAProxy image tag to use when not specified.
 	ArgoCDDefaultRedisHAProxyVersion = "sha256:7392fbbbb53e9e063ca94891da6656e6062f9d021c0e514888a91535b9f73231" // 2.0.25-alpine
 
+	// ArgoCDDefaultRedisExporterImage is the Redis metrics exporter container image to use when not specified.
+	ArgoCDDefaultRedisExporterImage = "oliver006/redis_exporter"
+
+	// ArgoCDDefaultRedisExporterPort is the default listen port for the Redis metrics exporter.
+	ArgoCDDefaultRedisExporterPort = 9121
+
+	// ArgoCDDefaultRedisExporterVersion is the Redis metrics exporter container image tag to use when not specified.
+	ArgoCDDefaultRedisExporterVersion = "v1.58.0"
+
 	// ArgoCDDefaultRedisImage is the Redis container image to use when not specified.
 	ArgoCDDefaultRedisImage = "redis"
 
+	// ArgoCDDefaultRedisMaxMemoryPercent is the percentage of the memory limit of the Redis container used as the
+	// default --maxmemory of Redis.
+	ArgoCDDefaultRedisMaxMemoryPercent = 80
+
+	// ArgoCDDefaultRedisMaxMemoryPolicy is the default key eviction policy of Redis once --maxmemory is reached.
+	ArgoCDDefaultRedisMaxMemoryPolicy = "allkeys-lru"
+
 	// ArgoCDDefaultRedisPort is the default listen port for Redis.
 	ArgoCDDefaultRedisPort = 6379
 
@@ -172,10 +172,46 @@ const (
 	// to used for the Dex container.
 	ArgoCDDexImageEnvName = "ARGOCD_DEX_IMAGE"
 
+	// ArgoCDReconcileTimeoutEnvName is the environment variable used to get the maximum duration of a single
+	// ArgoCD reconcile.
+	ArgoCDReconcileTimeoutEnvName = "ARGOCD_RECONCILE_TIMEOUT"
+
+	// ArgoCDClusterDomainEnvName is the environment variable used to get the DNS domain of the cluster, used in the
+	// FQDN of the services.
+	ArgoCDClusterDomainEnvName = "CLUSTER_DOMAIN"
+
+	// ArgoCDResyncPeriodEnvName is the environment variable used to get the interval after which a successfully
+	// reconciled ArgoCD is reconciled again.
+	ArgoCDResyncPeriodEnvName = "ARGOCD_RESYNC_PERIOD"
+
+	// ArgoCDAppSetSourceNamespacesConcurrencyEnvName is the environment variable used to get the maximum number
+	// of ApplicationSet source namespaces reconciled in parallel.
+	ArgoCDAppSetSourceNamespacesConcurrencyEnvName = "ARGOCD_APPLICATIONSET_SOURCE_NAMESPACES_CONCURRENCY"
+
+	// ArgoCDAppSetDisableClusterRolesEnvName is the environment variable used to prevent the operator from creating
+	// the cluster role and cluster role binding of the ApplicationSet controller of cluster-scoped instances.
+	ArgoCDAppSetDisableClusterRolesEnvName = "ARGOCD_APPLICATIONSET_DISABLE_CLUSTER_ROLES"
+
+	// ArgoCDAppSetDefaultResourcesEnvName is the environment variable used to override the default resource
+	// requirements of the ApplicationSet controller container, as JSON.
+	ArgoCDAppSetDefaultResourcesEnvName = "ARGOCD_APPLICATIONSET_DEFAULT_RESOURCES"
+
+	// ArgoCDRedisDefaultResourcesEnvName is the environment variable used to override the default resource
+	// requirements of the Redis container, as JSON.
+	ArgoCDRedisDefaultResourcesEnvName = "ARGOCD_REDIS_DEFAULT_RESOURCES"
+
+	// ArgoCDRedisHADefaultResourcesEnvName is the environment variable used to override the default resource
+	// requirements of the Redis HA containers, as JSON.
+	ArgoCDRedisHADefaultResourcesEnvName = "ARGOCD_REDIS_HA_DEFAULT_RESOURCES"
+
 	// ArgoCDImageEnvName is the environment variable used to get the image
 	// to used for the argocd container.
 	ArgoCDImageEnvName = "ARGOCD_IMAGE"
 
+	// ArgoCDImageRegistryEnvName is the environment variable used to get the registry
+	// replacing the registry of the default container images, e.g. a mirror in air-gapped environments.
+	ArgoCDImageRegistryEnvName = "ARGOCD_IMAGE_REGISTRY"
+
 	// ArgoCDKeycloakImageEnvName is the environment variable used to get the image
 	// to used for the Keycloak container.
 	ArgoCDKeycloakImageEnvName = "ARGOCD_KEYCLOAK_IMAGE"
@@ -192,6 +228,10 @@ const (
 	// to used for the Redis container.
 	ArgoCDRedisImageEnvName = "ARGOCD_REDIS_IMAGE"
 
+	// ArgoCDRedisExporterImageEnvName is the environment variable used to get the image
+	// to used for the Redis metrics exporter container.
+	ArgoCDRedisExporterImageEnvName = "ARGOCD_REDIS_EXPORTER_IMAGE"
+
 	// ArgoCDDeletionFinalizer is a finalizer to implement pre-delete hooks
 	ArgoCDDeletionFinalizer = "argoproj.io/finalizer"
 
@@ -216,6 +256,10 @@ const (
 	// ArgoCDServerClusterRoleEnvName is an environment variable to specify a custom cluster role for Argo CD server
 	ArgoCDServerClusterRoleEnvName = "SERVER_CLUSTER_ROLE"
 
+	// ArgoCDNotificationsDefaultSubscriptionsEnvName is an environment variable holding the default global notifications
+	// subscriptions, in YAML, that are merged into the subscriptions of every NotificationsConfiguration.
+	ArgoCDNotificationsDefaultSubscriptionsEnvName = "ARGOCD_NOTIFICATIONS_DEFAULT_SUBSCRIPTIONS"
+
 	// ArgoCDDexSecretKey is used to reference Dex secret from Argo CD secret into Argo CD configmap
 	ArgoCDDexSecretKey = "oidc.dex.clientSecret"
 
@@ -18,17 +18,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	amerr "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -39,17 +45,105 @@ import (
 
 const (
 	ApplicationSetGitlabSCMTlsCertPath = "/app/tls/scm/cert"
+
+	// reasons of the warning events emitted for ApplicationSet configuration issues
+	invalidApplicationSetSourceNamespacesReason = "InvalidApplicationSetSourceNamespaces"
+	applicationSetRepoServerDisabledReason      = "ApplicationSetRepoServerDisabled"
+	applicationSetAPIMissingReason              = "ApplicationSetAPIMissing"
+	applicationSetKnownHostsMissingReason       = "ApplicationSetKnownHostsConfigMapMissing"
+	invalidApplicationSetPolicyReason           = "InvalidApplicationSetPolicy"
+	invalidApplicationSetSCMProvidersReason     = "InvalidApplicationSetSCMProviders"
+
+	// applicationSetReadinessProbePort is the webhook port of the ApplicationSet controller, which only accepts
+	// connections once the controller serves webhooks
+	applicationSetReadinessProbePort = 7000
+	// applicationSetLivenessProbePort is the --probe-addr port of the controller manager of the ApplicationSet
+	// controller. It is probed over TCP, as the controller registers no /healthz or /readyz check, so the listener
+	// answers these paths with 404 even when the controller is healthy.
+	applicationSetLivenessProbePort = 8081
+
+	applicationSetServiceAccountTokenVolumeName = "serviceaccount-token"
+
+	applicationSetTrustedCAVolumeName = "trusted-ca"
+	applicationSetTrustedCAPath       = "/app/config/trusted-ca"
+
+	// serviceAccountTokenMountPath is where the in-cluster client config reads the service account token from
+	serviceAccountTokenMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	// defaultServiceAccountTokenExpirationSeconds matches the lifetime of the automounted bound token
+	defaultServiceAccountTokenExpirationSeconds = 3607
 )
 
+// applicationSetAPIFound is set when inspecting the cluster. It defaults to true, so that the ApplicationSet
+// controller is deployed when the cluster has not been inspected.
+var applicationSetAPIFound = true
+
+// IsApplicationSetAPIAvailable returns true if the Application and ApplicationSet APIs are present.
+func IsApplicationSetAPIAvailable() bool {
+	return applicationSetAPIFound
+}
+
+// verifyApplicationSetAPI will verify that the Application and ApplicationSet APIs, watched by the ApplicationSet
+// controller, are present.
+func verifyApplicationSetAPI() error {
+	found, err := argoutil.VerifyAPIResources("argoproj.io", "v1alpha1", "applications", "applicationsets")
+	if err != nil {
+		return err
+	}
+	applicationSetAPIFound = found
+	return nil
+}
+
+// getEnabledApplicationSetSourceNamespaces returns the ApplicationSet source namespaces that are also source namespaces of
+// the Applications, as appset source namespaces should be a subset of apps source namespaces.
+func (r *ReconcileArgoCD) getEnabledApplicationSetSourceNamespaces(cr *argoproj.ArgoCD) ([]string, error) {
+	appsetsSourceNamespaces := []string{}
+	if cr.Spec.ApplicationSet == nil || len(cr.Spec.ApplicationSet.SourceNamespaces) == 0 {
+		return appsetsSourceNamespaces, nil
+	}
+	appsNamespaces, err := r.getSourceNamespaces(cr)
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range cr.Spec.ApplicationSet.SourceNamespaces {
+		if contains(appsNamespaces, ns) {
+			appsetsSourceNamespaces = append(appsetsSourceNamespaces, ns)
+		} else {
+			log.V(1).Info(fmt.Sprintf("Apps in target sourceNamespace %s is not enabled, thus skipping the namespace in deployment command.", ns))
+		}
+	}
+	return appsetsSourceNamespaces, nil
+}
+
+// applicationSetPolicies are the values of the --policy flag of the ApplicationSet controller.
+var applicationSetPolicies = []string{"sync", "create-only", "create-update", "create-delete"}
+
+// getApplicationSetPolicy returns the policy of the ApplicationSet controller, or an empty string when it is not set
+// or not valid, for the controller default to be used.
+func getApplicationSetPolicy(cr *argoproj.ArgoCD) string {
+	if cr.Spec.ApplicationSet == nil || !contains(applicationSetPolicies, cr.Spec.ApplicationSet.Policy) {
+		return ""
+	}
+	return cr.Spec.ApplicationSet.Policy
+}
+
 // getArgoApplicationSetCommand will return the command for the ArgoCD ApplicationSet component.
-func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) []string {
+func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) ([]string, error) {
 	cmd := make([]string, 0)
 
-	cmd = append(cmd, "entrypoint.sh")
-	cmd = append(cmd, "argocd-applicationset-controller")
+	// a custom command replaces the default entrypoint, the computed flags are still appended to it
+	if len(cr.Spec.ApplicationSet.Command) > 0 {
+		cmd = append(cmd, cr.Spec.ApplicationSet.Command...)
+	} else {
+		cmd = append(cmd, "entrypoint.sh")
+		cmd = append(cmd, "argocd-applicationset-controller")
+	}
 
 	if cr.Spec.Repo.IsEnabled() {
 		cmd = append(cmd, "--argocd-repo-server", getRepoServerAddress(cr))
+		if timeout := cr.Spec.ApplicationSet.GitTimeout; timeout != nil && timeout.Duration > 0 {
+			cmd = append(cmd, "--repo-server-timeout-seconds", fmt.Sprint(int64(math.Ceil(timeout.Duration.Seconds()))))
+		}
 	} else {
 		log.Info("Repo Server is disabled. This would affect the functioning of ApplicationSet Controller.")
 	}
@@ -57,22 +151,22 @@ func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) []st
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.ApplicationSet.LogLevel))
 
+	if policy := getApplicationSetPolicy(cr); policy != "" {
+		cmd = append(cmd, "--policy", policy)
+	}
+
+	if len(cr.Spec.ApplicationSet.PreservedAnnotations) > 0 {
+		cmd = append(cmd, "--preserved-annotations", strings.Join(cr.Spec.ApplicationSet.PreservedAnnotations, ","))
+	}
+
 	if cr.Spec.ApplicationSet.SCMRootCAConfigMap != "" {
 		cmd = append(cmd, "--scm-root-ca-path")
 		cmd = append(cmd, ApplicationSetGitlabSCMTlsCertPath)
 	}
 
-	// appset source namespaces should be subset of apps source namespaces
-	appsetsSourceNamespaces := []string{}
-	appsNamespaces, err := r.getSourceNamespaces(cr)
-	if err == nil {
-		for _, ns := range cr.Spec.ApplicationSet.SourceNamespaces {
-			if contains(appsNamespaces, ns) {
-				appsetsSourceNamespaces = append(appsetsSourceNamespaces, ns)
-			} else {
-				log.V(1).Info(fmt.Sprintf("Apps in target sourceNamespace %s is not enabled, thus skipping the namespace in deployment command.", ns))
-			}
-		}
+	appsetsSourceNamespaces, err := r.getEnabledApplicationSetSourceNamespaces(cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source namespaces for applicationset command: %w", err)
 	}
 
 	if len(appsetsSourceNamespaces) > 0 {
@@ -84,74 +178,107 @@ func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) []st
 	}
 
 	// appset in any ns is enabled and no scmProviders allow list is specified,
-	// disables scm & PR generators to prevent potential security issues
+	// disables scm & PR generators to prevent potential security issues. The controller refuses to start in that
+	// case without one of both flags, so the generators are disabled even if explicitly enabled in the spec.
 	// https://argo-cd.readthedocs.io/en/stable/operator-manual/applicationset/Appset-Any-Namespace/#scm-providers-secrets-consideration
-	if len(appsetsSourceNamespaces) > 0 && !(len(cr.Spec.ApplicationSet.SCMProviders) > 0) {
+	if disable := cr.Spec.ApplicationSet.DisableSCMProviders; disable != nil && *disable {
 		cmd = append(cmd, "--enable-scm-providers=false")
+	} else if len(appsetsSourceNamespaces) > 0 && !(len(cr.Spec.ApplicationSet.SCMProviders) > 0) {
+		cmd = append(cmd, "--enable-scm-providers=false")
+	}
+
+	// leader election is required when running more than one replica, which the autoscaler may do at any time
+	if replicas := cr.Spec.ApplicationSet.Replicas; (replicas != nil && *replicas > 1) || isApplicationSetAutoscaleEnabled(cr) {
+		cmd = append(cmd, "--enable-leader-election")
 	}
 
 	// ApplicationSet command arguments provided by the user
 	extraArgs := cr.Spec.ApplicationSet.ExtraCommandArgs
 	err = isMergable(extraArgs, cmd)
 	if err != nil {
-		return cmd
+		return cmd, nil
 	}
 
 	cmd = append(cmd, extraArgs...)
 
-	return cmd
+	return cmd, nil
 }
 
-func (r *ReconcileArgoCD) reconcileApplicationSetController(cr *argoproj.ArgoCD) error {
+// reconcileApplicationSetController will ensure all the ApplicationSet controller resources are present. All client
+// calls share ctx, so the reconcile stops once ctx is cancelled or its deadline expires.
+func (r *ReconcileArgoCD) reconcileApplicationSetController(ctx context.Context, cr *argoproj.ArgoCD) error {
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	log.Info("reconciling applicationset serviceaccounts")
-	sa, err := r.reconcileApplicationSetServiceAccount(cr)
+	sa, err := r.reconcileApplicationSetServiceAccount(ctx, cr)
 	if err != nil {
 		return err
 	}
 
 	log.Info("reconciling applicationset roles")
-	role, err := r.reconcileApplicationSetRole(cr)
+	role, err := r.reconcileApplicationSetRole(ctx, cr)
 	if err != nil {
 		return err
 	}
 
 	log.Info("reconciling applicationset role bindings")
-	if err := r.reconcileApplicationSetRoleBinding(cr, role, sa); err != nil {
+	if err := r.reconcileApplicationSetRoleBinding(ctx, cr, role, sa); err != nil {
 		return err
 	}
 
-	log.Info("reconciling applicationset deployments")
-	if err := r.reconcileApplicationSetDeployment(cr, sa); err != nil {
+	log.Info("reconciling applicationset scm root ca configmap")
+	if err := r.reconcileApplicationSetSCMRootCAConfigMap(ctx, cr); err != nil {
 		return err
 	}
 
+	log.Info("reconciling applicationset deployments")
+	if cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.IsEnabled() && !IsApplicationSetAPIAvailable() {
+		// the controller crash-loops without the CRDs it watches, don't deploy it
+		message := "the ApplicationSet controller is not deployed as the applications.argoproj.io and applicationsets.argoproj.io CRDs are not installed"
+		if err := r.emitWarningEvent(cr, applicationSetAPIMissingReason, message); err != nil {
+			return err
+		}
+	} else {
+		r.clearWarningEvent(cr, applicationSetAPIMissingReason)
+		if err := r.reconcileApplicationSetDeployment(ctx, cr, sa); err != nil {
+			return err
+		}
+	}
+
 	log.Info("reconciling applicationset service")
-	if err := r.reconcileApplicationSetService(cr); err != nil {
+	if err := r.reconcileApplicationSetService(ctx, cr); err != nil {
+		return err
+	}
+
+	log.Info("reconciling applicationset network policy")
+	if err := r.reconcileApplicationSetNetworkPolicy(ctx, cr); err != nil {
 		return err
 	}
 
 	// create clusterrole & clusterrolebinding if cluster-scoped ArgoCD
 	log.Info("reconciling applicationset clusterroles")
-	clusterrole, err := r.reconcileApplicationSetClusterRole(cr)
+	clusterrole, err := r.reconcileApplicationSetClusterRole(ctx, cr)
 	if err != nil {
 		return err
 	}
 
 	log.Info("reconciling applicationset clusterrolebindings")
-	if err := r.reconcileApplicationSetClusterRoleBinding(cr, clusterrole, sa); err != nil {
+	if err := r.reconcileApplicationSetClusterRoleBinding(ctx, cr, clusterrole, sa); err != nil {
 		return err
 	}
 
 	// reconcile source namespace roles & rolebindings
 	log.Info("reconciling applicationset roles & rolebindings in source namespaces")
-	if err := r.reconcileApplicationSetSourceNamespacesResources(cr); err != nil {
+	if err := r.reconcileApplicationSetSourceNamespacesResources(ctx, cr); err != nil {
 		return err
 	}
 
 	// remove resources for namespaces not part of SourceNamespaces
 	log.Info("performing cleanup for applicationset source namespaces")
-	if err := r.removeUnmanagedApplicationSetSourceNamespaceResources(cr); err != nil {
+	if err := r.removeUnmanagedApplicationSetSourceNamespaceResources(ctx, cr); err != nil {
 		return err
 	}
 
@@ -159,16 +286,16 @@ func (r *ReconcileArgoCD) reconcileApplicationSetController(cr *argoproj.ArgoCD)
 }
 
 // reconcileApplicationControllerDeployment will ensure the Deployment resource is present for the ArgoCD Application Controller component.
-func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD, sa *corev1.ServiceAccount) error {
+func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(ctx context.Context, cr *argoproj.ArgoCD, sa *corev1.ServiceAccount) error {
 
-	exists := false
 	existing := newDeploymentWithSuffix("applicationset-controller", "controller", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		exists = true
+	exists, err := argoutil.ObjectExistsWithContext(ctx, r.Client, cr.Namespace, existing.Name, existing)
+	if err != nil {
+		return err
 	}
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() {
 		if exists {
-			return r.Client.Delete(context.TODO(), existing)
+			return r.Client.Delete(ctx, existing)
 		}
 		return nil
 	}
@@ -177,19 +304,35 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 
 	setAppSetLabels(&deploy.ObjectMeta)
 
+	// the replicas are managed by the HorizontalPodAutoscaler while autoscaling is enabled,
+	// otherwise they default to 1 like the API server does, so that the stored deployment
+	// compares equal when spec.applicationSet.replicas is unset
+	if !isApplicationSetAutoscaleEnabled(cr) {
+		replicas := int32(1)
+		if cr.Spec.ApplicationSet.Replicas != nil {
+			replicas = *cr.Spec.ApplicationSet.Replicas
+		}
+		deploy.Spec.Replicas = &replicas
+	}
+
 	podSpec := &deploy.Spec.Template.Spec
+	applyDNSSettings(podSpec, cr)
 
 	// sa would be nil when spec.applicationset.enabled = false
 	if sa != nil {
 		podSpec.ServiceAccountName = sa.ObjectMeta.Name
 	}
+	knownHostsConfigMapName, err := r.getApplicationSetKnownHostsConfigMapName(ctx, cr)
+	if err != nil {
+		return err
+	}
 	podSpec.Volumes = []corev1.Volume{
 		{
 			Name: "ssh-known-hosts",
 			VolumeSource: corev1.VolumeSource{
 				ConfigMap: &corev1.ConfigMapVolumeSource{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: common.ArgoCDKnownHostsConfigMapName,
+						Name: knownHostsConfigMapName,
 					},
 				},
 			},
@@ -230,7 +373,11 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 	addSCMGitlabVolumeMount := false
 	if scmRootCAConfigMapName := getSCMRootCAConfigMapName(cr); scmRootCAConfigMapName != "" {
 		cm := newConfigMapWithName(scmRootCAConfigMapName, cr)
-		if argoutil.IsObjectFound(r.Client, cr.Namespace, cr.Spec.ApplicationSet.SCMRootCAConfigMap, cm) {
+		found, err := argoutil.ObjectExistsWithContext(ctx, r.Client, cr.Namespace, cr.Spec.ApplicationSet.SCMRootCAConfigMap, cm)
+		if err != nil {
+			return err
+		}
+		if found {
 			addSCMGitlabVolumeMount = true
 			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
 				Name: "appset-gitlab-scm-tls-cert",
@@ -245,35 +392,90 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 		}
 	}
 
-	podSpec.Containers = []corev1.Container{
-		r.applicationSetContainer(cr, addSCMGitlabVolumeMount),
+	if isApplicationSetServiceAccountTokenEnabled(cr) {
+		// the projected token replaces the automounted one at the same path
+		podSpec.AutomountServiceAccountToken = boolPtr(false)
+		podSpec.Volumes = append(podSpec.Volumes, getApplicationSetServiceAccountTokenVolume(cr))
+	}
+
+	addTrustedCAVolumeMount := false
+	if name := cr.Spec.ApplicationSet.TrustedCAConfigMap; name != "" {
+		found, err := argoutil.ObjectExistsWithContext(ctx, r.Client, cr.Namespace, name, newConfigMapWithName(name, cr))
+		if err != nil {
+			return err
+		}
+		if found {
+			addTrustedCAVolumeMount = true
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: applicationSetTrustedCAVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: name,
+						},
+					},
+				},
+			})
+		} else {
+			log.Info(fmt.Sprintf("trusted CA config map %s of the ApplicationSet controller not found, it is not mounted", name))
+		}
+	}
+
+	// user volumes replace operator volumes with the same name, so the pod spec never has duplicate volume names
+	podSpec.Volumes = mergeVolumes(podSpec.Volumes, cr.Spec.ApplicationSet.Volumes)
+
+	container, err := r.applicationSetContainer(cr, addSCMGitlabVolumeMount, addTrustedCAVolumeMount)
+	if err != nil {
+		return err
+	}
+	podSpec.Containers = []corev1.Container{container}
+
+	// sidecars are appended after the applicationset controller container,
+	// which must always remain the first container in the pod spec
+	if cr.Spec.ApplicationSet.SidecarContainers != nil {
+		podSpec.Containers = append(podSpec.Containers, cr.Spec.ApplicationSet.SidecarContainers...)
 	}
-	AddSeccompProfileForOpenShift(r.Client, podSpec)
+	AddSeccompProfileForOpenShiftWithContext(ctx, r.Client, podSpec)
 
 	if exists {
 
 		existingSpec := existing.Spec.Template.Spec
+		if isApplicationSetAutoscaleEnabled(cr) {
+			deploy.Spec.Replicas = existing.Spec.Replicas
+		}
 
-		deploymentsDifferent := !reflect.DeepEqual(existingSpec.Containers[0], podSpec.Containers) ||
-			!reflect.DeepEqual(existingSpec.Volumes, podSpec.Volumes) ||
+		dnsChanged := false
+		updateDNSSettings(&existing.Spec.Template.Spec, podSpec, &dnsChanged)
+
+		deploymentsDifferent := dnsChanged || applicationSetContainersDifferent(existingSpec.Containers, podSpec.Containers) ||
+			!equality.Semantic.DeepEqual(withVolumeDefaults(existingSpec.Volumes), withVolumeDefaults(podSpec.Volumes)) ||
+			existingSpec.Containers[0].ImagePullPolicy != podSpec.Containers[0].ImagePullPolicy ||
+			!reflect.DeepEqual(existingSpec.Containers[0].ReadinessProbe, podSpec.Containers[0].ReadinessProbe) ||
+			!reflect.DeepEqual(existingSpec.Containers[0].LivenessProbe, podSpec.Containers[0].LivenessProbe) ||
 			existingSpec.ServiceAccountName != podSpec.ServiceAccountName ||
+			!reflect.DeepEqual(existingSpec.AutomountServiceAccountToken, podSpec.AutomountServiceAccountToken) ||
+			!reflect.DeepEqual(existing.Spec.Replicas, deploy.Spec.Replicas) ||
 			!reflect.DeepEqual(existing.Labels, deploy.Labels) ||
 			!reflect.DeepEqual(existing.Spec.Template.Labels, deploy.Spec.Template.Labels) ||
 			!reflect.DeepEqual(existing.Spec.Selector, deploy.Spec.Selector) ||
 			!reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, deploy.Spec.Template.Spec.NodeSelector) ||
-			!reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, deploy.Spec.Template.Spec.Tolerations)
+			!reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, deploy.Spec.Template.Spec.Tolerations) ||
+			!reflect.DeepEqual(existing.Spec.Template.Spec.HostAliases, deploy.Spec.Template.Spec.HostAliases)
 
 		// If the Deployment already exists, make sure the values we care about are up-to-date
 		if deploymentsDifferent {
 			existing.Spec.Template.Spec.Containers = podSpec.Containers
 			existing.Spec.Template.Spec.Volumes = podSpec.Volumes
 			existing.Spec.Template.Spec.ServiceAccountName = podSpec.ServiceAccountName
+			existing.Spec.Template.Spec.AutomountServiceAccountToken = podSpec.AutomountServiceAccountToken
+			existing.Spec.Replicas = deploy.Spec.Replicas
 			existing.Labels = deploy.Labels
 			existing.Spec.Template.Labels = deploy.Spec.Template.Labels
 			existing.Spec.Selector = deploy.Spec.Selector
 			existing.Spec.Template.Spec.NodeSelector = deploy.Spec.Template.Spec.NodeSelector
 			existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
-			return r.Client.Update(context.TODO(), existing)
+			existing.Spec.Template.Spec.HostAliases = deploy.Spec.Template.Spec.HostAliases
+			return r.Client.Update(ctx, existing)
 		}
 		return nil // Deployment found with nothing to do, move along...
 	}
@@ -285,11 +487,188 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), deploy)
+	return r.Client.Create(ctx, deploy)
+
+}
+
+// applicationSetContainersDifferent reports whether the existing containers differ from the desired ones. Fields that
+// are only defaulted by the API server are set to their defaults on both sides before comparing, so an unchanged ArgoCD
+// doesn't cause an update of the deployment on every reconcile. The applicationset controller container is compared in
+// the fields set by the operator, the sidecar containers are compared in full.
+func applicationSetContainersDifferent(existing []corev1.Container, desired []corev1.Container) bool {
+	if len(existing) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		e, d := withContainerDefaults(existing[i]), withContainerDefaults(desired[i])
+		if i > 0 {
+			if !equality.Semantic.DeepEqual(e, d) {
+				return true
+			}
+			continue
+		}
+		if e.Name != d.Name || e.Image != d.Image || e.ImagePullPolicy != d.ImagePullPolicy ||
+			!equality.Semantic.DeepEqual(e.Command, d.Command) ||
+			!equality.Semantic.DeepEqual(e.Args, d.Args) ||
+			!equality.Semantic.DeepEqual(e.Env, d.Env) ||
+			!equality.Semantic.DeepEqual(e.VolumeMounts, d.VolumeMounts) ||
+			!equality.Semantic.DeepEqual(e.Resources, d.Resources) ||
+			!equality.Semantic.DeepEqual(e.SecurityContext, d.SecurityContext) {
+			return true
+		}
+	}
+	return false
+}
+
+// withContainerDefaults returns a copy of the container with the fields defaulted by the API server set to their
+// defaults, so that a container of a stored object can be compared with the desired one.
+func withContainerDefaults(container corev1.Container) corev1.Container {
+	c := *container.DeepCopy()
+	if c.ImagePullPolicy == "" {
+		c.ImagePullPolicy = defaultImagePullPolicy(c.Image)
+	}
+	if c.TerminationMessagePath == "" {
+		c.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	}
+	if c.TerminationMessagePolicy == "" {
+		c.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	}
+	for i := range c.Ports {
+		if c.Ports[i].Protocol == "" {
+			c.Ports[i].Protocol = corev1.ProtocolTCP
+		}
+	}
+	c.Env = withEnvDefaults(c.Env)
+	setProbeDefaults(c.LivenessProbe)
+	setProbeDefaults(c.ReadinessProbe)
+	setProbeDefaults(c.StartupProbe)
+	if c.Lifecycle != nil {
+		setLifecycleHandlerDefaults(c.Lifecycle.PostStart)
+		setLifecycleHandlerDefaults(c.Lifecycle.PreStop)
+	}
+	return c
+}
+
+// defaultImagePullPolicy returns the pull policy the API server sets for the image when none is given: Always for
+// images with the latest tag or without a tag, IfNotPresent otherwise.
+func defaultImagePullPolicy(image string) corev1.PullPolicy {
+	if strings.Contains(image, "@") {
+		return corev1.PullIfNotPresent
+	}
+	tag := ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		tag = image[i+1:]
+	}
+	if tag == "" || tag == "latest" {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
+
+// setProbeDefaults sets the fields of the probe defaulted by the API server to their defaults.
+func setProbeDefaults(probe *corev1.Probe) {
+	if probe == nil {
+		return
+	}
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = 1
+	}
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = 10
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = 1
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = 3
+	}
+	if probe.HTTPGet != nil && probe.HTTPGet.Scheme == "" {
+		probe.HTTPGet.Scheme = corev1.URISchemeHTTP
+	}
+	if probe.GRPC != nil && probe.GRPC.Service == nil {
+		service := ""
+		probe.GRPC.Service = &service
+	}
+}
+
+// setLifecycleHandlerDefaults sets the fields of the lifecycle handler defaulted by the API server to their defaults.
+func setLifecycleHandlerDefaults(handler *corev1.LifecycleHandler) {
+	if handler != nil && handler.HTTPGet != nil && handler.HTTPGet.Scheme == "" {
+		handler.HTTPGet.Scheme = corev1.URISchemeHTTP
+	}
+}
+
+// withVolumeDefaults returns a copy of the volumes with the fields defaulted by the API server set to their defaults,
+// so that the volumes of a stored object can be compared with the desired ones.
+func withVolumeDefaults(volumes []corev1.Volume) []corev1.Volume {
+	defaultMode := corev1.ConfigMapVolumeSourceDefaultMode
+	defaultExpirationSeconds := int64(3600)
+	defaulted := make([]corev1.Volume, 0, len(volumes))
+	for _, volume := range volumes {
+		v := *volume.DeepCopy()
+		if v.ConfigMap != nil && v.ConfigMap.DefaultMode == nil {
+			v.ConfigMap.DefaultMode = &defaultMode
+		}
+		if v.Secret != nil && v.Secret.DefaultMode == nil {
+			v.Secret.DefaultMode = &defaultMode
+		}
+		if v.DownwardAPI != nil {
+			if v.DownwardAPI.DefaultMode == nil {
+				v.DownwardAPI.DefaultMode = &defaultMode
+			}
+			setDownwardAPIDefaults(v.DownwardAPI.Items)
+		}
+		if v.Projected != nil {
+			if v.Projected.DefaultMode == nil {
+				v.Projected.DefaultMode = &defaultMode
+			}
+			for _, source := range v.Projected.Sources {
+				if source.ServiceAccountToken != nil && source.ServiceAccountToken.ExpirationSeconds == nil {
+					source.ServiceAccountToken.ExpirationSeconds = &defaultExpirationSeconds
+				}
+				if source.DownwardAPI != nil {
+					setDownwardAPIDefaults(source.DownwardAPI.Items)
+				}
+			}
+		}
+		if v.HostPath != nil && v.HostPath.Type == nil {
+			hostPathType := corev1.HostPathUnset
+			v.HostPath.Type = &hostPathType
+		}
+		defaulted = append(defaulted, v)
+	}
+	return defaulted
+}
 
+// setDownwardAPIDefaults sets the API version of the field references of the items to its default.
+func setDownwardAPIDefaults(items []corev1.DownwardAPIVolumeFile) {
+	for i := range items {
+		if items[i].FieldRef != nil && items[i].FieldRef.APIVersion == "" {
+			items[i].FieldRef.APIVersion = "v1"
+		}
+	}
+}
+
+// withEnvDefaults returns a copy of the env with the fields defaulted by the API server set to their defaults, so
+// that the env of a stored object can be compared with the desired one.
+func withEnvDefaults(env []corev1.EnvVar) []corev1.EnvVar {
+	defaulted := make([]corev1.EnvVar, 0, len(env))
+	for _, envVar := range env {
+		e := *envVar.DeepCopy()
+		if e.ValueFrom != nil && e.ValueFrom.FieldRef != nil && e.ValueFrom.FieldRef.APIVersion == "" {
+			e.ValueFrom.FieldRef.APIVersion = "v1"
+		}
+		defaulted = append(defaulted, e)
+	}
+	return defaulted
 }
 
-func (r *ReconcileArgoCD) applicationSetContainer(cr *argoproj.ArgoCD, addSCMGitlabVolumeMount bool) corev1.Container {
+func (r *ReconcileArgoCD) applicationSetContainer(cr *argoproj.ArgoCD, addSCMGitlabVolumeMount bool, addTrustedCAVolumeMount bool) (corev1.Container, error) {
+	cmd, err := r.getArgoApplicationSetCommand(cr)
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
 	// Global proxy env vars go first
 	appSetEnv := []corev1.EnvVar{{
 		Name: "NAMESPACE",
@@ -300,6 +679,14 @@ func (r *ReconcileArgoCD) applicationSetContainer(cr *argoproj.ArgoCD, addSCMGit
 		},
 	}}
 
+	// the certificates of the trusted CA config map are loaded along with the system ones
+	if addTrustedCAVolumeMount {
+		appSetEnv = append(appSetEnv, corev1.EnvVar{
+			Name:  "SSL_CERT_DIR",
+			Value: "/etc/ssl/certs:" + applicationSetTrustedCAPath,
+		})
+	}
+
 	// Merge ApplicationSet env vars provided by the user
 	// User should be able to override the default NAMESPACE environmental variable
 	appSetEnv = argoutil.EnvMerge(cr.Spec.ApplicationSet.Env, appSetEnv, true)
@@ -307,10 +694,10 @@ func (r *ReconcileArgoCD) applicationSetContainer(cr *argoproj.ArgoCD, addSCMGit
 	appSetEnv = argoutil.EnvMerge(appSetEnv, proxyEnvVars(), false)
 
 	container := corev1.Container{
-		Command:         r.getArgoApplicationSetCommand(cr),
+		Command:         cmd,
 		Env:             appSetEnv,
 		Image:           getApplicationSetContainerImage(cr),
-		ImagePullPolicy: corev1.PullAlways,
+		ImagePullPolicy: getApplicationSetImagePullPolicy(cr),
 		Name:            "argocd-applicationset-controller",
 		Resources:       getApplicationSetResources(cr),
 		VolumeMounts: []corev1.VolumeMount{
@@ -356,22 +743,39 @@ func (r *ReconcileArgoCD) applicationSetContainer(cr *argoproj.ArgoCD, addSCMGit
 			RunAsNonRoot:             boolPtr(true),
 		},
 	}
+	container.ReadinessProbe = getApplicationSetProbe(cr.Spec.ApplicationSet.ReadinessProbe, applicationSetReadinessProbePort)
+	container.LivenessProbe = getApplicationSetProbe(cr.Spec.ApplicationSet.LivenessProbe, applicationSetLivenessProbePort)
 	if addSCMGitlabVolumeMount {
 		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
 			Name:      "appset-gitlab-scm-tls-cert",
 			MountPath: ApplicationSetGitlabSCMTlsCertPath,
 		})
 	}
-	return container
+	if addTrustedCAVolumeMount {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      applicationSetTrustedCAVolumeName,
+			MountPath: applicationSetTrustedCAPath,
+			ReadOnly:  true,
+		})
+	}
+	if isApplicationSetServiceAccountTokenEnabled(cr) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      applicationSetServiceAccountTokenVolumeName,
+			MountPath: serviceAccountTokenMountPath,
+			ReadOnly:  true,
+		})
+	}
+	container.VolumeMounts = mergeVolumeMounts(container.VolumeMounts, cr.Spec.ApplicationSet.VolumeMounts)
+	return container, nil
 }
 
-func (r *ReconcileArgoCD) reconcileApplicationSetServiceAccount(cr *argoproj.ArgoCD) (*corev1.ServiceAccount, error) {
+func (r *ReconcileArgoCD) reconcileApplicationSetServiceAccount(ctx context.Context, cr *argoproj.ArgoCD) (*corev1.ServiceAccount, error) {
 
 	sa := newServiceAccountWithName("applicationset-controller", cr)
 	setAppSetLabels(&sa.ObjectMeta)
 
 	exists := true
-	if err := argoutil.FetchObject(r.Client, cr.Namespace, sa.Name, sa); err != nil {
+	if err := argoutil.FetchObjectWithContext(ctx, r.Client, cr.Namespace, sa.Name, sa); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return sa, err
 		}
@@ -380,7 +784,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetServiceAccount(cr *argoproj.Arg
 
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() {
 		if exists {
-			err := r.Client.Delete(context.TODO(), sa)
+			err := r.Client.Delete(ctx, sa)
 			if err != nil {
 				if !apierrors.IsNotFound(err) {
 					return sa, err
@@ -391,21 +795,124 @@ func (r *ReconcileArgoCD) reconcileApplicationSetServiceAccount(cr *argoproj.Arg
 	}
 
 	if !exists {
+		applyServiceAccountAnnotations(sa, cr.Spec.ApplicationSet.ServiceAccountAnnotations)
+		applyApplicationSetMetadata(cr, &sa.ObjectMeta)
 		if err := controllerutil.SetControllerReference(cr, sa, r.Scheme); err != nil {
 			return sa, err
 		}
 
-		err := r.Client.Create(context.TODO(), sa)
+		err := r.Client.Create(ctx, sa)
 		if err != nil {
 			return sa, err
 		}
+		return sa, nil
+	}
+
+	changed := applyServiceAccountAnnotations(sa, cr.Spec.ApplicationSet.ServiceAccountAnnotations)
+	if setAppSetLabels(&sa.ObjectMeta) {
+		changed = true
+	}
+	if applyApplicationSetMetadata(cr, &sa.ObjectMeta) {
+		changed = true
+	}
+	if changed {
+		if err := r.Client.Update(ctx, sa); err != nil {
+			return sa, err
+		}
 	}
 
 	return sa, nil
 }
 
+// reconcileApplicationSetSCMRootCAConfigMap copies the CA data of the user provided SCMRootCAConfigMap into the
+// well-known ConfigMap mounted on the ApplicationSet controller, keeping it in sync when the source changes.
+// The copy is deleted once SCMRootCAConfigMap is cleared.
+func (r *ReconcileArgoCD) reconcileApplicationSetSCMRootCAConfigMap(ctx context.Context, cr *argoproj.ArgoCD) error {
+
+	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() {
+		r.trackSCMRootCAConfigMap(cr, "")
+		return nil
+	}
+
+	sourceName := getSCMRootCAConfigMapName(cr)
+	if sourceName == common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName {
+		r.trackSCMRootCAConfigMap(cr, "")
+		return nil
+	}
+	r.trackSCMRootCAConfigMap(cr, sourceName)
+	if sourceName == "" {
+		return r.deleteApplicationSetSCMRootCAConfigMapCopy(ctx, cr)
+	}
+
+	source := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: sourceName, Namespace: cr.Namespace}, source); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("scm root ca configmap [%s] not found, waiting to reconcile configmap [%s]", sourceName, common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName))
+			return nil
+		}
+		return err
+	}
+
+	cm := newConfigMapWithName(common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName, cr)
+	cm.Data = source.Data
+	cm.BinaryData = source.BinaryData
+
+	existing := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("creating configmap %s for Argo CD instance %s in namespace %s", cm.Name, cr.Name, cr.Namespace))
+		return r.Client.Create(ctx, cm)
+	}
+
+	if reflect.DeepEqual(existing.Data, cm.Data) && reflect.DeepEqual(existing.BinaryData, cm.BinaryData) {
+		return nil
+	}
+	existing.Data = cm.Data
+	existing.BinaryData = cm.BinaryData
+	log.Info(fmt.Sprintf("updating configmap %s for Argo CD instance %s in namespace %s", existing.Name, cr.Name, cr.Namespace))
+	return r.Client.Update(ctx, existing)
+}
+
+// deleteApplicationSetSCMRootCAConfigMapCopy deletes the copy of the SCMRootCAConfigMap made by the operator. A
+// ConfigMap with the same name that was created by the user is kept.
+func (r *ReconcileArgoCD) deleteApplicationSetSCMRootCAConfigMapCopy(ctx context.Context, cr *argoproj.ArgoCD) error {
+	existing := &corev1.ConfigMap{}
+	exists, err := argoutil.ObjectExistsWithContext(ctx, r.Client, cr.Namespace, common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName, existing)
+	if err != nil || !exists || !metav1.IsControlledBy(existing, cr) {
+		return err
+	}
+	log.Info(fmt.Sprintf("deleting configmap %s for Argo CD instance %s in namespace %s", existing.Name, cr.Name, cr.Namespace))
+	return r.Client.Delete(ctx, existing)
+}
+
+// trackSCMRootCAConfigMap records the config map set as SCMRootCAConfigMap of the given ArgoCD, so that the events of
+// that config map can be mapped back to the ArgoCD without listing the ArgoCD instances. An empty name stops tracking.
+func (r *ReconcileArgoCD) trackSCMRootCAConfigMap(cr *argoproj.ArgoCD, name string) {
+	key := types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}
+
+	r.scmRootCAConfigMapsMutex.Lock()
+	defer r.scmRootCAConfigMapsMutex.Unlock()
+	for cm, argocd := range r.scmRootCAConfigMaps {
+		if argocd == key {
+			delete(r.scmRootCAConfigMaps, cm)
+		}
+	}
+	if name == "" {
+		return
+	}
+	if r.scmRootCAConfigMaps == nil {
+		r.scmRootCAConfigMaps = make(map[types.NamespacedName]types.NamespacedName)
+	}
+	r.scmRootCAConfigMaps[types.NamespacedName{Name: name, Namespace: cr.Namespace}] = key
+}
+
 // reconcileApplicationSetClusterRoleBinding reconciles required clusterrole for appset controller when ArgoCD is cluster-scoped
-func (r *ReconcileArgoCD) reconcileApplicationSetClusterRole(cr *argoproj.ArgoCD) (*v1.ClusterRole, error) {
+func (r *ReconcileArgoCD) reconcileApplicationSetClusterRole(ctx context.Context, cr *argoproj.ArgoCD) (*v1.ClusterRole, error) {
 
 	allowed := false
 	if allowedNamespace(cr.Namespace, os.Getenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES")) {
@@ -417,6 +924,14 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRole(cr *argoproj.ArgoCD
 		allowed = false
 	}
 
+	// cluster roles disabled by the administrator, the controller is restricted to namespaced permissions
+	if allowed && isApplicationSetClusterRoleDisabled() {
+		log.Info(fmt.Sprintf("%s is set, not creating the applicationset clusterrole of Argo CD instance %s in namespace %s: "+
+			"the applicationset controller only has namespaced permissions and features requiring cluster-wide access are degraded",
+			common.ArgoCDAppSetDisableClusterRolesEnvName, cr.Name, cr.Namespace))
+		allowed = false
+	}
+
 	policyRules := []v1.PolicyRule{
 		// ApplicationSet
 		{
@@ -449,7 +964,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRole(cr *argoproj.ArgoCD
 	}
 
 	existingClusterRole := &v1.ClusterRole{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRole.Name}, existingClusterRole)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: clusterRole.Name}, existingClusterRole)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return nil, fmt.Errorf("failed to reconcile the cluster role for the service account associated with %s : %s", clusterRole.Name, err)
@@ -458,12 +973,16 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRole(cr *argoproj.ArgoCD
 			// Do Nothing
 			return clusterRole, nil
 		}
-		return clusterRole, r.Client.Create(context.TODO(), clusterRole)
+		return clusterRole, r.Client.Create(ctx, clusterRole)
 	}
 
 	// ArgoCD not cluster scoped, cleanup any existing resource and exit
 	if !allowed {
-		err := r.Client.Delete(context.TODO(), existingClusterRole)
+		if !isClusterResourceOwnedBy(existingClusterRole, cr) {
+			log.Info(fmt.Sprintf("skipping deletion of clusterrole %s as it does not belong to Argo CD instance %s in namespace %s", existingClusterRole.Name, cr.Name, cr.Namespace))
+			return clusterRole, nil
+		}
+		err := r.Client.Delete(ctx, existingClusterRole)
 		if err != nil {
 			if !apierrors.IsNotFound(err) {
 				return existingClusterRole, err
@@ -475,7 +994,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRole(cr *argoproj.ArgoCD
 	// if the Rules differ, update the Role
 	if !reflect.DeepEqual(existingClusterRole.Rules, clusterRole.Rules) {
 		existingClusterRole.Rules = clusterRole.Rules
-		if err := r.Client.Update(context.TODO(), existingClusterRole); err != nil {
+		if err := r.Client.Update(ctx, existingClusterRole); err != nil {
 			return nil, err
 		}
 	}
@@ -483,7 +1002,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRole(cr *argoproj.ArgoCD
 }
 
 // reconcileApplicationSetClusterRoleBinding reconciles required clusterrolebinding for appset controller when ArgoCD is cluster-scoped
-func (r *ReconcileArgoCD) reconcileApplicationSetClusterRoleBinding(cr *argoproj.ArgoCD, role *v1.ClusterRole, sa *corev1.ServiceAccount) error {
+func (r *ReconcileArgoCD) reconcileApplicationSetClusterRoleBinding(ctx context.Context, cr *argoproj.ArgoCD, role *v1.ClusterRole, sa *corev1.ServiceAccount) error {
 
 	allowed := false
 	if allowedNamespace(cr.Namespace, os.Getenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES")) {
@@ -495,6 +1014,11 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRoleBinding(cr *argoproj
 		allowed = false
 	}
 
+	// cluster roles disabled by the administrator, see reconcileApplicationSetClusterRole
+	if isApplicationSetClusterRoleDisabled() {
+		allowed = false
+	}
+
 	clusterRB := newClusterRoleBindingWithname(common.ArgoCDApplicationSetControllerComponent, cr)
 	clusterRB.Subjects = []v1.Subject{
 		{
@@ -513,7 +1037,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRoleBinding(cr *argoproj
 	}
 
 	existingClusterRB := &v1.ClusterRoleBinding{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRB.Name}, existingClusterRB)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: clusterRB.Name}, existingClusterRB)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return fmt.Errorf("failed to reconcile the cluster rolebinding for the service account associated with %s : %s", clusterRB.Name, err)
@@ -522,12 +1046,16 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRoleBinding(cr *argoproj
 			// Do Nothing
 			return nil
 		}
-		return r.Client.Create(context.TODO(), clusterRB)
+		return r.Client.Create(ctx, clusterRB)
 	}
 
 	// ArgoCD not cluster scoped, cleanup any existing resource and exit
 	if !allowed {
-		err := r.Client.Delete(context.TODO(), existingClusterRB)
+		if !isClusterResourceOwnedBy(existingClusterRB, cr) {
+			log.Info(fmt.Sprintf("skipping deletion of clusterrolebinding %s as it does not belong to Argo CD instance %s in namespace %s", existingClusterRB.Name, cr.Name, cr.Namespace))
+			return nil
+		}
+		err := r.Client.Delete(ctx, existingClusterRB)
 		if err != nil {
 			if !apierrors.IsNotFound(err) {
 				return err
@@ -539,143 +1067,344 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRoleBinding(cr *argoproj
 	// if subj differ, update the rolebinding
 	if !reflect.DeepEqual(existingClusterRB.Subjects, clusterRB.Subjects) {
 		existingClusterRB.Subjects = clusterRB.Subjects
-		if err := r.Client.Update(context.TODO(), existingClusterRB); err != nil {
+		if err := r.Client.Update(ctx, existingClusterRB); err != nil {
 			return err
 		}
 	} else if !reflect.DeepEqual(existingClusterRB.RoleRef, clusterRB.RoleRef) {
 		// RoleRef can't be updated, delete the rolebinding so that it gets recreated
-		_ = r.Client.Delete(context.TODO(), existingClusterRB)
+		_ = r.Client.Delete(ctx, existingClusterRB)
 		return fmt.Errorf("change detected in roleRef for rolebinding %s of Argo CD instance %s in namespace %s", existingClusterRB.Name, cr.Name, existingClusterRB.Namespace)
 	}
 	return nil
 }
 
-// reconcileApplicationSetSourceNamespacesResources creates role & rolebinding in target source namespaces for appset controller
-// Appset resources are only created if target source ns is subset of apps source namespaces
-func (r *ReconcileArgoCD) reconcileApplicationSetSourceNamespacesResources(cr *argoproj.ArgoCD) error {
+// validateApplicationSet emits warning events for ApplicationSet configurations that are accepted by the API but
+// leave the ApplicationSet controller partially functional.
+func (r *ReconcileArgoCD) validateApplicationSet(cr *argoproj.ArgoCD) error {
+	if err := r.validateApplicationSetSourceNamespaces(cr); err != nil {
+		return err
+	}
+	if err := r.validateApplicationSetPolicy(cr); err != nil {
+		return err
+	}
+	if err := r.validateApplicationSetSCMProviders(cr); err != nil {
+		return err
+	}
+	return r.validateApplicationSetRepoServer(cr)
+}
 
-	var reconciliationErrors []error
+// validateApplicationSetSCMProviders emits a warning event when the SCM providers are explicitly enabled for
+// ApplicationSets in any namespace without an SCMProviders allow list, as the controller does not start in that
+// case and the SCM providers stay disabled.
+func (r *ReconcileArgoCD) validateApplicationSetSCMProviders(cr *argoproj.ArgoCD) error {
+	if cr.Spec.ApplicationSet == nil || cr.Spec.ApplicationSet.DisableSCMProviders == nil ||
+		*cr.Spec.ApplicationSet.DisableSCMProviders || len(cr.Spec.ApplicationSet.SCMProviders) > 0 {
+		r.clearWarningEvent(cr, invalidApplicationSetSCMProvidersReason)
+		return nil
+	}
 
-	// controller disabled, nothing to do. cleanup handled by removeUnmanagedApplicationSetSourceNamespaceResources()
-	if cr.Spec.ApplicationSet == nil {
+	appsetsSourceNamespaces, err := r.getEnabledApplicationSetSourceNamespaces(cr)
+	if err != nil {
+		return err
+	}
+	if len(appsetsSourceNamespaces) == 0 {
+		r.clearWarningEvent(cr, invalidApplicationSetSCMProvidersReason)
 		return nil
 	}
 
-	// create resources for each appset source namespace
-	for _, sourceNamespace := range cr.Spec.ApplicationSet.SourceNamespaces {
+	message := "ApplicationSet SCM providers stay disabled as they require .spec.applicationSet.scmProviders to be set when .spec.applicationSet.sourceNamespaces is"
+	return r.emitWarningEvent(cr, invalidApplicationSetSCMProvidersReason, message)
+}
+
+// validateApplicationSetPolicy emits a warning event when the policy of the ApplicationSet controller is not valid,
+// as it is ignored.
+func (r *ReconcileArgoCD) validateApplicationSetPolicy(cr *argoproj.ArgoCD) error {
+	if cr.Spec.ApplicationSet == nil || cr.Spec.ApplicationSet.Policy == "" || getApplicationSetPolicy(cr) != "" {
+		r.clearWarningEvent(cr, invalidApplicationSetPolicyReason)
+		return nil
+	}
 
-		// source ns should be part of app-in-any-ns
+	message := fmt.Sprintf("ApplicationSet policy %q is ignored as it is not one of [%s], the controller default is used",
+		cr.Spec.ApplicationSet.Policy, strings.Join(applicationSetPolicies, ", "))
+	return r.emitWarningEvent(cr, invalidApplicationSetPolicyReason, message)
+}
+
+// validateApplicationSetSourceNamespaces emits a warning event listing the ApplicationSet source namespaces that are
+// not part of the Apps source namespaces, as the ApplicationSet controller ignores them.
+func (r *ReconcileArgoCD) validateApplicationSetSourceNamespaces(cr *argoproj.ArgoCD) error {
+	var invalidNamespaces []string
+	if cr.Spec.ApplicationSet != nil && len(cr.Spec.ApplicationSet.SourceNamespaces) > 0 {
 		appsNamespaces, err := r.getSourceNamespaces(cr)
 		if err != nil {
-			reconciliationErrors = append(reconciliationErrors, err)
-			continue
+			return err
 		}
-		if !contains(appsNamespaces, sourceNamespace) {
-			log.Error(fmt.Errorf("skipping reconciliation of resources for sourceNamespace %s as Apps in target sourceNamespace is not enabled", sourceNamespace), "Warning")
-			continue
+		for _, ns := range cr.Spec.ApplicationSet.SourceNamespaces {
+			if !contains(appsNamespaces, ns) {
+				invalidNamespaces = append(invalidNamespaces, ns)
+			}
 		}
+	}
+
+	if len(invalidNamespaces) == 0 {
+		r.clearWarningEvent(cr, invalidApplicationSetSourceNamespacesReason)
+		return nil
+	}
+
+	message := fmt.Sprintf("ApplicationSet source namespaces [%s] are ignored as they are not part of .spec.sourceNamespaces", strings.Join(invalidNamespaces, ", "))
+	return r.emitWarningEvent(cr, invalidApplicationSetSourceNamespacesReason, message)
+}
+
+// validateApplicationSetRepoServer emits a warning event when the ApplicationSet controller is enabled while the
+// Repo server is disabled, as generators relying on the Repo server (e.g. Git) will not work.
+func (r *ReconcileArgoCD) validateApplicationSetRepoServer(cr *argoproj.ArgoCD) error {
+	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() || cr.Spec.Repo.IsEnabled() {
+		r.clearWarningEvent(cr, applicationSetRepoServerDisabledReason)
+		return nil
+	}
+
+	message := "ApplicationSet controller is enabled but the Repo server is disabled, generators relying on the Repo server will not work"
+	return r.emitWarningEvent(cr, applicationSetRepoServerDisabledReason, message)
+}
+
+// reconcileApplicationSetSourceNamespacesResources creates role & rolebinding in target source namespaces for appset controller
+// Appset resources are only created if target source ns is subset of apps source namespaces
+// Source namespaces are reconciled in parallel, bounded by getApplicationSetSourceNamespacesConcurrency.
+func (r *ReconcileArgoCD) reconcileApplicationSetSourceNamespacesResources(ctx context.Context, cr *argoproj.ArgoCD) error {
+
+	// controller disabled, nothing to do. cleanup handled by removeUnmanagedApplicationSetSourceNamespaceResources()
+	if cr.Spec.ApplicationSet == nil {
+		return r.updateApplicationSetSourceNamespacesStatus(ctx, cr, nil)
+	}
+
+	sourceNamespaces := cr.Spec.ApplicationSet.SourceNamespaces
+	if len(sourceNamespaces) == 0 {
+		return r.updateApplicationSetSourceNamespacesStatus(ctx, cr, nil)
+	}
+
+	// source ns should be part of app-in-any-ns
+	appsNamespaces, appsNamespacesErr := r.getSourceNamespaces(cr)
 
-		// skip source ns if doesn't exist
-		namespace := &corev1.Namespace{}
-		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sourceNamespace}, namespace); err != nil {
-			errMsg := fmt.Errorf("failed to retrieve namespace %s", sourceNamespace)
-			reconciliationErrors = append(reconciliationErrors, errors.Join(errMsg, err))
+	var (
+		reconciliationErrors = make([][]error, len(sourceNamespaces))
+		namespaceStatuses    = make([]argoproj.ArgoCDApplicationSetSourceNamespaceStatus, len(sourceNamespaces))
+		mu                   sync.Mutex
+		wg                   sync.WaitGroup
+		sem                  = make(chan struct{}, getApplicationSetSourceNamespacesConcurrency())
+	)
+
+	// create resources for each appset source namespace
+	for i, sourceNamespace := range sourceNamespaces {
+		if err := ctx.Err(); err != nil {
+			reconciliationErrors[i] = []error{err}
+			namespaceStatuses[i] = newApplicationSetSourceNamespaceStatus(sourceNamespace, argoproj.ApplicationSetSourceNamespaceFailed, err.Error())
+			continue
+		}
+		if appsNamespacesErr != nil {
+			reconciliationErrors[i] = []error{appsNamespacesErr}
+			namespaceStatuses[i] = newApplicationSetSourceNamespaceStatus(sourceNamespace, argoproj.ApplicationSetSourceNamespaceFailed, appsNamespacesErr.Error())
 			continue
 		}
 
-		// No namespace can be managed by multiple argo-cd instances (cluster scoped or namespace scoped)
-		// i.e, only one of either managed-by or applicationset-managed-by-cluster-argocd labels can be applied to a given namespace.
-		// Since appset-in-any-ns is in beta, we prioritize managed-by label in case of a conflict.
-		if value, ok := namespace.Labels[common.ArgoCDManagedByLabel]; ok && value != "" {
-			log.Info(fmt.Sprintf("Skipping reconciling resources for namespace %s as it is already managed-by namespace %s.", namespace.Name, value))
-			// remove any source namespace resources
-			if val, ok1 := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; ok1 && val != cr.Namespace {
-				delete(r.ManagedApplicationSetSourceNamespaces, namespace.Name)
-				if err := r.cleanupUnmanagedApplicationSetSourceNamespaceResources(cr, namespace.Name); err != nil {
-					log.Error(err, fmt.Sprintf("error cleaning up resources for namespace %s", namespace.Name))
-				}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, sourceNamespace string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			namespaceStatuses[i], reconciliationErrors[i] = r.reconcileApplicationSetSourceNamespaceResources(ctx, cr, sourceNamespace, appsNamespaces, &mu)
+		}(i, sourceNamespace)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, namespaceErrors := range reconciliationErrors {
+		errs = append(errs, namespaceErrors...)
+	}
+
+	if err := r.updateApplicationSetSourceNamespacesStatus(ctx, cr, namespaceStatuses); err != nil {
+		errs = append(errs, err)
+	}
+
+	return amerr.NewAggregate(errs)
+}
+
+// reconcileApplicationSetSourceNamespaceResources creates role & rolebinding for appset controller in a single source namespace.
+// It returns the resulting state of the namespace along with any errors encountered. mu guards writes to
+// ManagedApplicationSetSourceNamespaces, since source namespaces are reconciled concurrently.
+func (r *ReconcileArgoCD) reconcileApplicationSetSourceNamespaceResources(ctx context.Context, cr *argoproj.ArgoCD, sourceNamespace string, appsNamespaces []string, mu *sync.Mutex) (argoproj.ArgoCDApplicationSetSourceNamespaceStatus, []error) {
+
+	if !contains(appsNamespaces, sourceNamespace) {
+		log.Error(fmt.Errorf("skipping reconciliation of resources for sourceNamespace %s as Apps in target sourceNamespace is not enabled", sourceNamespace), "Warning")
+		return newApplicationSetSourceNamespaceStatus(sourceNamespace, argoproj.ApplicationSetSourceNamespaceSkipped, "Apps in target sourceNamespace is not enabled"), nil
+	}
+
+	// skip source ns if doesn't exist
+	namespace := &corev1.Namespace{}
+	if err := r.getNamespace(ctx, sourceNamespace, namespace); err != nil {
+		errMsg := errors.Join(fmt.Errorf("failed to retrieve namespace %s", sourceNamespace), err)
+		return newApplicationSetSourceNamespaceStatus(sourceNamespace, argoproj.ApplicationSetSourceNamespaceFailed, errMsg.Error()), []error{errMsg}
+	}
+
+	// No namespace can be managed by multiple argo-cd instances (cluster scoped or namespace scoped)
+	// i.e, only one of either managed-by or applicationset-managed-by-cluster-argocd labels can be applied to a given namespace.
+	// Since appset-in-any-ns is in beta, we prioritize managed-by label in case of a conflict.
+	if value, ok := namespace.Labels[common.ArgoCDManagedByLabel]; ok && value != "" {
+		log.Info(fmt.Sprintf("Skipping reconciling resources for namespace %s as it is already managed-by namespace %s.", namespace.Name, value))
+		// remove any source namespace resources
+		if val, ok1 := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; ok1 && val != cr.Namespace {
+			mu.Lock()
+			delete(r.ManagedApplicationSetSourceNamespaces, namespace.Name)
+			mu.Unlock()
+			if err := r.cleanupUnmanagedApplicationSetSourceNamespaceResources(ctx, cr, namespace.Name); err != nil {
+				log.Error(err, fmt.Sprintf("error cleaning up resources for namespace %s", namespace.Name))
 			}
-			continue
 		}
+		return newApplicationSetSourceNamespaceStatus(sourceNamespace, argoproj.ApplicationSetSourceNamespaceSkipped, fmt.Sprintf("namespace is already managed-by namespace %s", value)), nil
+	}
+
+	// The applicationset-managed-by-cluster-argocd label records the namespace of the ArgoCD owning the source namespace.
+	// The first instance labelling it owns it, so instances contending for a namespace don't relabel or clean it up
+	// in turn. Other instances only manage it once the owner has removed its label.
+	if owner := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; owner != "" && owner != cr.Namespace {
+		return r.skipApplicationSetSourceNamespaceOwnedByOther(ctx, cr, namespace.Name, owner, mu)
+	}
 
-		log.Info(fmt.Sprintf("Reconciling applicationset resources for %s", namespace.Name))
-		// add applicationset-managed-by-cluster-argocd label on namespace
-		if _, ok := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; !ok {
+	log.Info(fmt.Sprintf("Reconciling applicationset resources for %s", namespace.Name))
+	var namespaceErrors []error
+	// add applicationset-managed-by-cluster-argocd label on namespace
+	if _, ok := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; !ok {
+		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 			// Get the latest value of namespace before updating it
-			if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: namespace.Name}, namespace); err != nil {
+			if err := r.Client.Get(ctx, types.NamespacedName{Name: namespace.Name}, namespace); err != nil {
 				return err
 			}
+			if _, ok := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; ok {
+				return nil // label added in the meantime, nothing to update
+			}
 			// Update namespace with applicationset-managed-by-cluster-argocd label
 			if namespace.Labels == nil {
 				namespace.Labels = make(map[string]string)
 			}
 			namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel] = cr.Namespace
-			if err := r.Client.Update(context.TODO(), namespace); err != nil {
-				log.Error(err, fmt.Sprintf("failed to add label from namespace [%s]", namespace.Name))
-			}
-		}
-
-		// role & rolebinding for applicationset controller in source namespace
-		role := v1.Role{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      getResourceNameForApplicationSetSourceNamespaces(cr),
-				Namespace: sourceNamespace,
-				Labels:    argoutil.LabelsForCluster(cr),
-			},
-			Rules: policyRuleForApplicationSetController(),
-		}
-		err = r.reconcileSourceNamespaceRole(role, cr)
+			return r.Client.Update(ctx, namespace)
+		})
 		if err != nil {
-			reconciliationErrors = append(reconciliationErrors, err)
+			log.Error(err, fmt.Sprintf("failed to add label to namespace [%s]", namespace.Name))
+			namespaceErrors = append(namespaceErrors, fmt.Errorf("failed to add label to namespace %s: %w", namespace.Name, err))
+		} else if owner := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; owner != cr.Namespace {
+			// another instance labelled the namespace in the meantime
+			return r.skipApplicationSetSourceNamespaceOwnedByOther(ctx, cr, namespace.Name, owner, mu)
 		}
+	}
 
-		roleBinding := v1.RoleBinding{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        getResourceNameForApplicationSetSourceNamespaces(cr),
-				Labels:      argoutil.LabelsForCluster(cr),
-				Annotations: argoutil.AnnotationsForCluster(cr),
-				Namespace:   sourceNamespace,
-			},
-			RoleRef: v1.RoleRef{
-				APIGroup: v1.GroupName,
-				Kind:     "Role",
-				Name:     getResourceNameForApplicationSetSourceNamespaces(cr),
-			},
-			Subjects: []v1.Subject{
-				{
-					Kind:      v1.ServiceAccountKind,
-					Name:      getServiceAccountName(cr.Name, "applicationset-controller"),
-					Namespace: cr.Namespace,
-				},
+	// role & rolebinding for applicationset controller in source namespace
+	role := v1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getResourceNameForApplicationSetSourceNamespaces(cr),
+			Namespace: sourceNamespace,
+			Labels:    argoutil.LabelsForCluster(cr),
+		},
+		Rules: policyRuleForApplicationSetSourceNamespaces(cr),
+	}
+	if err := r.ensureRole(ctx, role, cr); err != nil {
+		namespaceErrors = append(namespaceErrors, err)
+	}
+
+	roleBinding := v1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getResourceNameForApplicationSetSourceNamespaces(cr),
+			Labels:      argoutil.LabelsForCluster(cr),
+			Annotations: argoutil.AnnotationsForCluster(cr),
+			Namespace:   sourceNamespace,
+		},
+		RoleRef: v1.RoleRef{
+			APIGroup: v1.GroupName,
+			Kind:     "Role",
+			Name:     getResourceNameForApplicationSetSourceNamespaces(cr),
+		},
+		Subjects: []v1.Subject{
+			{
+				Kind:      v1.ServiceAccountKind,
+				Name:      getServiceAccountName(cr.Name, "applicationset-controller"),
+				Namespace: cr.Namespace,
 			},
+		},
+	}
+	if err := r.ensureRoleBinding(ctx, roleBinding, cr); err != nil {
+		namespaceErrors = append(namespaceErrors, err)
+	}
+
+	// appset permissions for argocd server in source namespaces are handled by apps-in-any-ns code
+
+	mu.Lock()
+	if _, ok := r.ManagedApplicationSetSourceNamespaces[sourceNamespace]; !ok {
+		if r.ManagedApplicationSetSourceNamespaces == nil {
+			r.ManagedApplicationSetSourceNamespaces = make(map[string]string)
 		}
-		err = r.reconcileSourceNamespaceRoleBinding(roleBinding, cr)
-		if err != nil {
-			reconciliationErrors = append(reconciliationErrors, err)
-		}
+		r.ManagedApplicationSetSourceNamespaces[sourceNamespace] = ""
+	}
+	mu.Unlock()
+
+	if len(namespaceErrors) > 0 {
+		return newApplicationSetSourceNamespaceStatus(sourceNamespace, argoproj.ApplicationSetSourceNamespaceFailed, amerr.NewAggregate(namespaceErrors).Error()), namespaceErrors
+	}
+	return newApplicationSetSourceNamespaceStatus(sourceNamespace, argoproj.ApplicationSetSourceNamespaceReconciled, ""), nil
+}
 
-		// appset permissions for argocd server in source namespaces are handled by apps-in-any-ns code
+func newApplicationSetSourceNamespaceStatus(namespace, state, message string) argoproj.ArgoCDApplicationSetSourceNamespaceStatus {
+	return argoproj.ArgoCDApplicationSetSourceNamespaceStatus{
+		Name:    namespace,
+		State:   state,
+		Message: message,
+	}
+}
 
-		if _, ok := r.ManagedApplicationSetSourceNamespaces[sourceNamespace]; !ok {
-			if r.ManagedApplicationSetSourceNamespaces == nil {
-				r.ManagedApplicationSetSourceNamespaces = make(map[string]string)
-			}
-			r.ManagedApplicationSetSourceNamespaces[sourceNamespace] = ""
+// getApplicationSetSourceNamespacesConcurrency returns the maximum number of ApplicationSet source namespaces
+// reconciled in parallel, configured through the ARGOCD_APPLICATIONSET_SOURCE_NAMESPACES_CONCURRENCY environment variable.
+func getApplicationSetSourceNamespacesConcurrency() int {
+	if v := os.Getenv(common.ArgoCDAppSetSourceNamespacesConcurrencyEnvName); v != "" {
+		concurrency, err := strconv.Atoi(v)
+		if err == nil && concurrency > 0 {
+			return concurrency
 		}
+		log.Info(fmt.Sprintf("invalid value %q for %s, using the default of %d", v, common.ArgoCDAppSetSourceNamespacesConcurrencyEnvName, common.ArgoCDDefaultAppSetSourceNamespacesConcurrency))
+	}
+	return common.ArgoCDDefaultAppSetSourceNamespacesConcurrency
+}
+
+// isApplicationSetClusterRoleDisabled returns true if the operator must not create the cluster role and cluster role
+// binding of the ApplicationSet controller.
+func isApplicationSetClusterRoleDisabled() bool {
+	v := os.Getenv(common.ArgoCDAppSetDisableClusterRolesEnvName)
+	if v == "" {
+		return false
 	}
+	disabled, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Info(fmt.Sprintf("invalid value %q for %s, the applicationset cluster roles are not disabled", v, common.ArgoCDAppSetDisableClusterRolesEnvName))
+		return false
+	}
+	return disabled
+}
 
-	return amerr.NewAggregate(reconciliationErrors)
+// updateApplicationSetSourceNamespacesStatus will ensure that the ApplicationSetSourceNamespaces Status is updated for the given ArgoCD.
+func (r *ReconcileArgoCD) updateApplicationSetSourceNamespacesStatus(ctx context.Context, cr *argoproj.ArgoCD, statuses []argoproj.ArgoCDApplicationSetSourceNamespaceStatus) error {
+	if reflect.DeepEqual(cr.Status.ApplicationSetSourceNamespaces, statuses) {
+		return nil
+	}
+	cr.Status.ApplicationSetSourceNamespaces = statuses
+	return r.Client.Status().Update(ctx, cr)
 }
 
-func (r *ReconcileArgoCD) reconcileApplicationSetRole(cr *argoproj.ArgoCD) (*v1.Role, error) {
+func (r *ReconcileArgoCD) reconcileApplicationSetRole(ctx context.Context, cr *argoproj.ArgoCD) (*v1.Role, error) {
 
 	policyRules := policyRuleForApplicationSetController()
 
 	role := newRole("applicationset-controller", policyRules, cr)
-	setAppSetLabels(&role.ObjectMeta)
 
 	exists := true
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: role.Name, Namespace: cr.Namespace}, role)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: cr.Namespace}, role)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return role, err
@@ -685,7 +1414,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetRole(cr *argoproj.ArgoCD) (*v1.
 
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() {
 		if exists {
-			if err := r.Client.Delete(context.TODO(), role); err != nil {
+			if err := r.Client.Delete(ctx, role); err != nil {
 				if !apierrors.IsNotFound(err) {
 					return role, err
 				}
@@ -695,18 +1424,20 @@ func (r *ReconcileArgoCD) reconcileApplicationSetRole(cr *argoproj.ArgoCD) (*v1.
 	}
 
 	role.Rules = policyRules
+	setAppSetLabels(&role.ObjectMeta)
+	applyApplicationSetMetadata(cr, &role.ObjectMeta)
 	if err = controllerutil.SetControllerReference(cr, role, r.Scheme); err != nil {
 		return role, err
 	}
 	if exists {
-		return role, r.Client.Update(context.TODO(), role)
+		return role, r.Client.Update(ctx, role)
 	} else {
-		return role, r.Client.Create(context.TODO(), role)
+		return role, r.Client.Create(ctx, role)
 	}
 
 }
 
-func (r *ReconcileArgoCD) reconcileApplicationSetRoleBinding(cr *argoproj.ArgoCD, role *v1.Role, sa *corev1.ServiceAccount) error {
+func (r *ReconcileArgoCD) reconcileApplicationSetRoleBinding(ctx context.Context, cr *argoproj.ArgoCD, role *v1.Role, sa *corev1.ServiceAccount) error {
 
 	name := "applicationset-controller"
 
@@ -715,7 +1446,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetRoleBinding(cr *argoproj.ArgoCD
 
 	// fetch existing rolebinding by name
 	roleBindingExists := true
-	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: roleBinding.Name, Namespace: cr.Namespace}, roleBinding); err != nil {
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: roleBinding.Name, Namespace: cr.Namespace}, roleBinding); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return fmt.Errorf("failed to get the rolebinding associated with %s : %s", name, err)
 		}
@@ -724,13 +1455,20 @@ func (r *ReconcileArgoCD) reconcileApplicationSetRoleBinding(cr *argoproj.ArgoCD
 
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() {
 		if roleBindingExists {
-			return r.Client.Delete(context.TODO(), roleBinding)
+			return r.Client.Delete(ctx, roleBinding)
 		}
 		return nil
 	}
 
+	// the rolebinding must not reference a role that failed to be created
+	if role == nil || role.Name == "" {
+		return fmt.Errorf("applicationset controller role is not available, rolebinding %s will be reconciled once it is", roleBinding.Name)
+	}
+
 	setAppSetLabels(&roleBinding.ObjectMeta)
+	applyApplicationSetMetadata(cr, &roleBinding.ObjectMeta)
 
+	existingRoleRef := roleBinding.RoleRef
 	roleBinding.RoleRef = v1.RoleRef{
 		APIGroup: v1.GroupName,
 		Kind:     "Role",
@@ -750,44 +1488,37 @@ func (r *ReconcileArgoCD) reconcileApplicationSetRoleBinding(cr *argoproj.ArgoCD
 	}
 
 	if roleBindingExists {
-		return r.Client.Update(context.TODO(), roleBinding)
+		if reflect.DeepEqual(existingRoleRef, roleBinding.RoleRef) {
+			return r.Client.Update(ctx, roleBinding)
+		}
+
+		// RoleRef can't be updated, delete the rolebinding so that it gets recreated
+		if err := r.Client.Delete(ctx, roleBinding); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the rolebinding %s to update its roleRef : %w", roleBinding.Name, err)
+		}
+		log.Info(fmt.Sprintf("rolebinding %s deleted to update its roleRef", roleBinding.Name))
+		roleBinding.ResourceVersion = ""
+		roleBinding.UID = ""
 	}
 
-	return r.Client.Create(context.TODO(), roleBinding)
+	return r.Client.Create(ctx, roleBinding)
 }
 
 func getApplicationSetContainerImage(cr *argoproj.ArgoCD) string {
-	defaultImg, defaultTag := false, false
-
-	img := ""
-	tag := ""
-
-	// First pull from spec, if it exists
+	img, tag := "", ""
 	if cr.Spec.ApplicationSet != nil {
 		img = cr.Spec.ApplicationSet.Image
 		tag = cr.Spec.ApplicationSet.Version
 	}
-
-	// If spec is empty, use the defaults
-	if img == "" {
-		img = common.ArgoCDDefaultArgoImage
-		defaultImg = true
-	}
-	if tag == "" {
-		tag = common.ArgoCDDefaultArgoVersion
-		defaultTag = true
-	}
-
-	// If an env var is specified then use that, but don't override the spec values (if they are present)
-	if e := os.Getenv(common.ArgoCDImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
-	}
-	return argoutil.CombineImageTag(img, tag)
+	return resolveImage(img, tag, "", "",
+		common.ArgoCDDefaultArgoImage, common.ArgoCDDefaultArgoVersion, common.ArgoCDImageEnvName)
 }
 
 // getApplicationSetResources will return the ResourceRequirements for the Application Sets container.
 func getApplicationSetResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDAppSetDefaultResourcesEnvName,
+		common.ArgoCDDefaultApplicationSetResourceRequestCPU, common.ArgoCDDefaultApplicationSetResourceRequestMemory,
+		common.ArgoCDDefaultApplicationSetResourceLimitCPU, common.ArgoCDDefaultApplicationSetResourceLimitMemory)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.ApplicationSet.Resources != nil {
@@ -797,37 +1528,137 @@ func getApplicationSetResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements
 	return resources
 }
 
-func setAppSetLabels(obj *metav1.ObjectMeta) {
-	obj.Labels["app.kubernetes.io/name"] = "argocd-applicationset-controller"
-	obj.Labels["app.kubernetes.io/part-of"] = "argocd-applicationset"
-	obj.Labels["app.kubernetes.io/component"] = "controller"
+// getApplicationSetProbe returns a TCP probe against the given port of the ApplicationSet controller. Timings not
+// set in spec use the defaults. All fields defaulted by the API server are set explicitly, so that the probe does not
+// show up as drift.
+func getApplicationSetProbe(spec *argoproj.ArgoCDProbeSpec, port int) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(port),
+			},
+		},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+		TimeoutSeconds:      1,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+	if spec == nil {
+		return probe
+	}
+	if spec.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+	if spec.PeriodSeconds != nil {
+		probe.PeriodSeconds = *spec.PeriodSeconds
+	}
+	if spec.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+	if spec.FailureThreshold != nil {
+		probe.FailureThreshold = *spec.FailureThreshold
+	}
+	return probe
+}
+
+// getApplicationSetImagePullPolicy will return the ImagePullPolicy for the ApplicationSet container.
+func getApplicationSetImagePullPolicy(cr *argoproj.ArgoCD) corev1.PullPolicy {
+	policy := corev1.PullAlways
+
+	// Allow override of image pull policy from CR
+	if cr.Spec.ApplicationSet.ImagePullPolicy != "" {
+		policy = cr.Spec.ApplicationSet.ImagePullPolicy
+	}
+
+	return policy
+}
+
+// setAppSetLabels sets the labels identifying the ApplicationSet controller resources. Existing resources are
+// relabelled, so that the resources created by previous versions of the operator follow the current labels.
+// It returns true if the labels were changed.
+func setAppSetLabels(obj *metav1.ObjectMeta) bool {
+	return relabel(obj, map[string]string{
+		"app.kubernetes.io/name":      "argocd-applicationset-controller",
+		"app.kubernetes.io/part-of":   "argocd-applicationset",
+		"app.kubernetes.io/component": "controller",
+	})
+}
+
+// relabel sets the given labels on the object metadata, overwriting drifted values. It returns true if the labels
+// were changed.
+func relabel(obj *metav1.ObjectMeta, labels map[string]string) bool {
+	changed := false
+	for key, value := range labels {
+		if current, ok := obj.Labels[key]; ok && current == value {
+			continue
+		}
+		if obj.Labels == nil {
+			obj.Labels = make(map[string]string)
+		}
+		obj.Labels[key] = value
+		changed = true
+	}
+	return changed
+}
+
+// applyApplicationSetMetadata adds the labels and annotations of spec.applicationSet to the given object metadata,
+// overwriting any drifted values. The labels used by the operator to identify its resources are never overwritten.
+// It returns true if the metadata was changed.
+func applyApplicationSetMetadata(cr *argoproj.ArgoCD, obj *metav1.ObjectMeta) bool {
+	if cr.Spec.ApplicationSet == nil {
+		return false
+	}
+	changed := false
+	for key, value := range cr.Spec.ApplicationSet.Labels {
+		switch key {
+		case common.ArgoCDKeyName, common.ArgoCDKeyPartOf, common.ArgoCDKeyManagedBy, common.ArgoCDKeyComponent:
+			continue
+		}
+		if current, ok := obj.Labels[key]; ok && current == value {
+			continue
+		}
+		if obj.Labels == nil {
+			obj.Labels = make(map[string]string)
+		}
+		obj.Labels[key] = value
+		changed = true
+	}
+	for key, value := range cr.Spec.ApplicationSet.Annotations {
+		if current, ok := obj.Annotations[key]; ok && current == value {
+			continue
+		}
+		if obj.Annotations == nil {
+			obj.Annotations = make(map[string]string)
+		}
+		obj.Annotations[key] = value
+		changed = true
+	}
+	return changed
 }
 
 // reconcileApplicationSetService will ensure that the Service is present for the ApplicationSet webhook and metrics component.
-func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) reconcileApplicationSetService(ctx context.Context, cr *argoproj.ArgoCD) error {
 	log.Info("reconciling applicationset service")
 
 	svc := newServiceWithSuffix(common.ApplicationSetServiceNameSuffix, common.ApplicationSetServiceNameSuffix, cr)
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() {
 
-		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			err := argoutil.FetchObject(r.Client, cr.Namespace, svc.Name, svc)
-			if err != nil {
-				return err
-			}
+		found, err := argoutil.ObjectExistsWithContext(ctx, r.Client, cr.Namespace, svc.Name, svc)
+		if err != nil {
+			return err
+		}
+		if found {
 			log.Info(fmt.Sprintf("Deleting applicationset controller service %s as applicationset is disabled", svc.Name))
-			err = r.Delete(context.TODO(), svc)
+			err = r.Delete(ctx, svc)
 			if err != nil {
 				return err
 			}
 		}
 		return nil
-	} else {
-		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			return nil // Service found, do nothing
-		}
 	}
-	svc.Spec.Ports = []corev1.ServicePort{
+
+	ports := []corev1.ServicePort{
 		{
 			Name:       "webhook",
 			Port:       7000,
@@ -840,25 +1671,53 @@ func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoproj.ArgoCD) er
 			TargetPort: intstr.FromInt(8080),
 		},
 	}
-
-	svc.Spec.Selector = map[string]string{
+	selector := map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr),
 	}
 
+	// fetching the service overwrites the labels, keep the expected ones to relabel it
+	labels := argoutil.AppendStringMap(nil, svc.Labels)
+	found, err := argoutil.ObjectExistsWithContext(ctx, r.Client, cr.Namespace, svc.Name, svc)
+	if err != nil {
+		return err
+	}
+	if found {
+		changed := relabel(&svc.ObjectMeta, labels)
+		if applyApplicationSetMetadata(cr, &svc.ObjectMeta) {
+			changed = true
+		}
+		// revert changes made to the ports or selector outside of the operator
+		if !equality.Semantic.DeepEqual(svc.Spec.Ports, ports) || !reflect.DeepEqual(svc.Spec.Selector, selector) {
+			svc.Spec.Ports = ports
+			svc.Spec.Selector = selector
+			log.Info(fmt.Sprintf("Updating applicationset controller service %s to revert changes to its ports or selector", svc.Name))
+			changed = true
+		}
+		if changed {
+			return r.Client.Update(ctx, svc)
+		}
+		return nil // Service found with nothing to do, move along...
+	}
+
+	applyApplicationSetMetadata(cr, &svc.ObjectMeta)
+	svc.Spec.Ports = ports
+	svc.Spec.Selector = selector
+
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), svc)
+	return r.Client.Create(ctx, svc)
 }
 
-// Returns the name of the role/rolebinding for the source namespaces for applicationset-controller in the format of "argocdName-argocdNamespace-applicationset"
+// Returns the name of the role/rolebinding for the source namespaces for applicationset-controller in the format of "argocdName-argocdNamespace-applicationset",
+// truncated with a hash suffix when longer than 63 characters
 func getResourceNameForApplicationSetSourceNamespaces(cr *argoproj.ArgoCD) string {
-	return fmt.Sprintf("%s-%s-applicationset", cr.Name, cr.Namespace)
+	return truncateWithHash(fmt.Sprintf("%s-%s-applicationset", cr.Name, cr.Namespace), validation.DNS1123LabelMaxLength)
 }
 
 // removeUnmanagedApplicationSetSourceNamespaceResources cleansup resources from ApplicationSetSourceNamespaces if namespace is not managed by argocd instance.
 // ManagedApplicationSetSourceNamespaces var keeps track of namespaces with appset resources.
-func (r *ReconcileArgoCD) removeUnmanagedApplicationSetSourceNamespaceResources(cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) removeUnmanagedApplicationSetSourceNamespaceResources(ctx context.Context, cr *argoproj.ArgoCD) error {
 
 	for ns := range r.ManagedApplicationSetSourceNamespaces {
 		managedNamespace := false
@@ -877,7 +1736,7 @@ func (r *ReconcileArgoCD) removeUnmanagedApplicationSetSourceNamespaceResources(
 		}
 
 		if !managedNamespace {
-			if err := r.cleanupUnmanagedApplicationSetSourceNamespaceResources(cr, ns); err != nil {
+			if err := r.cleanupUnmanagedApplicationSetSourceNamespaceResources(ctx, cr, ns); err != nil {
 				log.Error(err, fmt.Sprintf("error cleaning up applicationset resources for namespace %s", ns))
 				continue
 			}
@@ -887,10 +1746,25 @@ func (r *ReconcileArgoCD) removeUnmanagedApplicationSetSourceNamespaceResources(
 	return nil
 }
 
-// cleanupUnmanagedApplicationSetSourceNamespaceResources removes the application set resources from target namespace
-func (r *ReconcileArgoCD) cleanupUnmanagedApplicationSetSourceNamespaceResources(cr *argoproj.ArgoCD, ns string) error {
+// skipApplicationSetSourceNamespaceOwnedByOther stops managing a source namespace owned by the ApplicationSet controller
+// of another ArgoCD, removing any resources of the given ArgoCD left in it.
+func (r *ReconcileArgoCD) skipApplicationSetSourceNamespaceOwnedByOther(ctx context.Context, cr *argoproj.ArgoCD, sourceNamespace string, owner string, mu *sync.Mutex) (argoproj.ArgoCDApplicationSetSourceNamespaceStatus, []error) {
+	log.Info(fmt.Sprintf("Skipping reconciling applicationset resources for namespace %s as it is already managed by the ArgoCD in namespace %s.", sourceNamespace, owner))
+	mu.Lock()
+	delete(r.ManagedApplicationSetSourceNamespaces, sourceNamespace)
+	mu.Unlock()
+	if err := r.cleanupUnmanagedApplicationSetSourceNamespaceResources(ctx, cr, sourceNamespace); err != nil {
+		log.Error(err, fmt.Sprintf("error cleaning up resources for namespace %s", sourceNamespace))
+	}
+	return newApplicationSetSourceNamespaceStatus(sourceNamespace, argoproj.ApplicationSetSourceNamespaceSkipped,
+		fmt.Sprintf("namespace is already managed by the applicationsets of the ArgoCD in namespace %s", owner)), nil
+}
+
+// cleanupUnmanagedApplicationSetSourceNamespaceResources removes the application set resources from target namespace.
+// The applicationset-managed-by-cluster-argocd label is only removed when it is owned by the given ArgoCD.
+func (r *ReconcileArgoCD) cleanupUnmanagedApplicationSetSourceNamespaceResources(ctx context.Context, cr *argoproj.ArgoCD, ns string) error {
 	namespace := corev1.Namespace{}
-	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ns}, &namespace); err != nil {
+	if err := r.getNamespace(ctx, ns, &namespace); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return err
 		}
@@ -900,13 +1774,13 @@ func (r *ReconcileArgoCD) cleanupUnmanagedApplicationSetSourceNamespaceResources
 	// Delete applicationset role & rolebinding
 	existingRole := v1.Role{}
 	roleName := getResourceNameForApplicationSetSourceNamespaces(cr)
-	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: roleName, Namespace: namespace.Name}, &existingRole); err != nil {
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: roleName, Namespace: namespace.Name}, &existingRole); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return fmt.Errorf("failed to fetch the role for the service account associated with %s : %s", common.ArgoCDApplicationSetControllerComponent, err)
 		}
 	}
 	if existingRole.Name != "" {
-		err := r.Client.Delete(context.TODO(), &existingRole)
+		err := r.Client.Delete(ctx, &existingRole)
 		if err != nil {
 			return err
 		}
@@ -914,22 +1788,25 @@ func (r *ReconcileArgoCD) cleanupUnmanagedApplicationSetSourceNamespaceResources
 
 	existingRoleBinding := &v1.RoleBinding{}
 	roleBindingName := getResourceNameForApplicationSetSourceNamespaces(cr)
-	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: roleBindingName, Namespace: namespace.Name}, existingRoleBinding); err != nil {
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: roleBindingName, Namespace: namespace.Name}, existingRoleBinding); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return fmt.Errorf("failed to get the rolebinding associated with %s : %s", common.ArgoCDApplicationSetControllerComponent, err)
 		}
 	}
 	if existingRoleBinding.Name != "" {
-		if err := r.Client.Delete(context.TODO(), existingRoleBinding); err != nil {
+		if err := r.Client.Delete(ctx, existingRoleBinding); err != nil {
 			return err
 		}
 	}
 
 	// app-in-any-ns code will handle removal of appsets permissions for argocd-server in target namespace
 
-	// Remove applicationset-managed-by-cluster-argocd label from the namespace
+	// Remove applicationset-managed-by-cluster-argocd label from the namespace, unless another instance owns it
+	if owner, ok := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; !ok || owner != cr.Namespace {
+		return nil
+	}
 	delete(namespace.Labels, common.ArgoCDApplicationSetManagedByClusterArgoCDLabel)
-	if err := r.Client.Update(context.TODO(), &namespace); err != nil {
+	if err := r.Client.Update(ctx, &namespace); err != nil {
 		return fmt.Errorf("failed to remove applicationset label from namespace %s : %s", namespace.Name, err)
 	}
 
@@ -938,7 +1815,7 @@ func (r *ReconcileArgoCD) cleanupUnmanagedApplicationSetSourceNamespaceResources
 
 // setManagedApplicationSetSourceNamespaces populates ManagedApplicationSetSourceNamespaces var with namespaces
 // with "argocd.argoproj.io/applicationset-managed-by-cluster-argocd" label.
-func (r *ReconcileArgoCD) setManagedApplicationSetSourceNamespaces(cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) setManagedApplicationSetSourceNamespaces(ctx context.Context, cr *argoproj.ArgoCD) error {
 	if r.ManagedApplicationSetSourceNamespaces == nil {
 		r.ManagedApplicationSetSourceNamespaces = make(map[string]string)
 	}
@@ -948,7 +1825,7 @@ func (r *ReconcileArgoCD) setManagedApplicationSetSourceNamespaces(cr *argoproj.
 	}
 
 	// get the list of namespaces managed with "argocd.argoproj.io/applicationset-managed-by-cluster-argocd" label
-	if err := r.Client.List(context.TODO(), namespaces, listOption); err != nil {
+	if err := r.Client.List(ctx, namespaces, listOption); err != nil {
 		return err
 	}
 
@@ -959,90 +1836,97 @@ func (r *ReconcileArgoCD) setManagedApplicationSetSourceNamespaces(cr *argoproj.
 	return nil
 }
 
-// reconcileSourceNamespaceRole creates/updates role
-func (r *ReconcileArgoCD) reconcileSourceNamespaceRole(role v1.Role, cr *argoproj.ArgoCD) error {
-
-	if err := applyReconcilerHook(cr, role, ""); err != nil {
-		return err
-	}
-
-	existingRole := v1.Role{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, &existingRole)
-	if err != nil {
-		if !apierrors.IsNotFound(err) {
-			errMsg := fmt.Errorf("failed to retrieve role %s in namespace %s", role.Name, role.Namespace)
-			return errors.Join(errMsg, err)
-		}
-
-		if err := r.Client.Create(context.TODO(), &role); err != nil {
-			errMsg := fmt.Errorf("failed to create role %s in namespace %s", role.Name, role.Namespace)
-			return errors.Join(errMsg, err)
-		}
-
-		log.Info(fmt.Sprintf("role %s created successfully for Argo CD instance %s in namespace %s", role.Name, cr.Name, role.Namespace))
-		return nil
-	}
-
-	// if the Rules differ, update the Role, ignore if role is just created.
-	if !reflect.DeepEqual(existingRole.Rules, role.Rules) {
-		existingRole.Rules = role.Rules
-		if err := r.Client.Update(context.TODO(), &existingRole); err != nil {
-			errMsg := fmt.Errorf("failed to update role %s in namespace %s", role.Name, role.Namespace)
-			return errors.Join(errMsg, err)
-		}
-		log.Info(fmt.Sprintf("role %s update successfully for Argo CD instance %s in namespace %s", role.Name, cr.Name, role.Namespace))
+// getApplicationSetSourceNamespaces return list of namespaces from .spec.ApplicationSet.SourceNamespaces
+func (r *ReconcileArgoCD) getApplicationSetSourceNamespaces(cr *argoproj.ArgoCD) []string {
+	if cr.Spec.ApplicationSet != nil {
+		return cr.Spec.ApplicationSet.SourceNamespaces
 	}
-
-	return nil
+	return []string(nil)
 }
 
-// reconcileSourceNamespaceRole creates/updates rolebinding
-func (r *ReconcileArgoCD) reconcileSourceNamespaceRoleBinding(roleBinding v1.RoleBinding, cr *argoproj.ArgoCD) error {
-
-	if err := applyReconcilerHook(cr, roleBinding, ""); err != nil {
-		return err
+// getApplicationSetKnownHostsConfigMapName returns the name of the SSH known hosts config map mounted on the
+// ApplicationSet controller. A warning event is emitted and the default config map is used when the config map set
+// in spec.applicationSet.sshKnownHostsConfigMap doesn't exist.
+func (r *ReconcileArgoCD) getApplicationSetKnownHostsConfigMapName(ctx context.Context, cr *argoproj.ArgoCD) (string, error) {
+	name := cr.Spec.ApplicationSet.SSHKnownHostsConfigMap
+	if name == "" || name == common.ArgoCDKnownHostsConfigMapName {
+		r.clearWarningEvent(cr, applicationSetKnownHostsMissingReason)
+		return common.ArgoCDKnownHostsConfigMapName, nil
 	}
 
-	existingRoleBinding := v1.RoleBinding{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: roleBinding.Name, Namespace: roleBinding.Namespace}, &existingRoleBinding)
+	found, err := argoutil.ObjectExistsWithContext(ctx, r.Client, cr.Namespace, name, newConfigMapWithName(name, cr))
 	if err != nil {
-		if !apierrors.IsNotFound(err) {
-			errMsg := fmt.Errorf("failed to retrieve rolebinding %s in namespace %s", roleBinding.Name, roleBinding.Namespace)
-			return errors.Join(errMsg, err)
-		}
-
-		if err := r.Client.Create(context.TODO(), &roleBinding); err != nil {
-			errMsg := fmt.Errorf("failed to create rolebinding %s in namespace %s", roleBinding.Name, roleBinding.Namespace)
-			return errors.Join(errMsg, err)
-		}
-
-		log.Info(fmt.Sprintf("rolebinding %s created successfully for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, roleBinding.Namespace))
-		return nil
+		return "", err
 	}
-
-	// if the RoleRef changes, delete the existing role binding and create a new one
-	if !reflect.DeepEqual(roleBinding.RoleRef, existingRoleBinding.RoleRef) {
-		if err = r.Client.Delete(context.TODO(), &existingRoleBinding); err != nil {
-			return err
-		}
-	} else {
-		// if the Subjects differ, update the role bindings
-		if !reflect.DeepEqual(roleBinding.Subjects, existingRoleBinding.Subjects) {
-			existingRoleBinding.Subjects = roleBinding.Subjects
-			if err = r.Client.Update(context.TODO(), &existingRoleBinding); err != nil {
-				return err
-			}
-			log.Info(fmt.Sprintf("rolebinding %s update successfully for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, roleBinding.Namespace))
+	if !found {
+		message := fmt.Sprintf("the SSH known hosts config map %s of the ApplicationSet controller is not found, %s is used instead",
+			name, common.ArgoCDKnownHostsConfigMapName)
+		if err := r.emitWarningEvent(cr, applicationSetKnownHostsMissingReason, message); err != nil {
+			return "", err
 		}
+		return common.ArgoCDKnownHostsConfigMapName, nil
 	}
 
-	return nil
+	r.clearWarningEvent(cr, applicationSetKnownHostsMissingReason)
+	return name, nil
 }
 
-// getApplicationSetSourceNamespaces return list of namespaces from .spec.ApplicationSet.SourceNamespaces
-func (r *ReconcileArgoCD) getApplicationSetSourceNamespaces(cr *argoproj.ArgoCD) []string {
-	if cr.Spec.ApplicationSet != nil {
-		return cr.Spec.ApplicationSet.SourceNamespaces
+// isApplicationSetServiceAccountTokenEnabled returns true if the ApplicationSet controller should use a projected
+// service account token instead of the automounted one.
+func isApplicationSetServiceAccountTokenEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.ServiceAccountToken != nil &&
+		cr.Spec.ApplicationSet.ServiceAccountToken.Enabled
+}
+
+// getApplicationSetServiceAccountTokenVolume returns the projected volume holding the bound service account token
+// of the ApplicationSet controller, along with the CA bundle and namespace files of the automounted token volume.
+func getApplicationSetServiceAccountTokenVolume(cr *argoproj.ArgoCD) corev1.Volume {
+	spec := cr.Spec.ApplicationSet.ServiceAccountToken
+	expirationSeconds := int64(defaultServiceAccountTokenExpirationSeconds)
+	if spec.ExpirationSeconds != nil {
+		expirationSeconds = *spec.ExpirationSeconds
+	}
+
+	return corev1.Volume{
+		Name: applicationSetServiceAccountTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          spec.Audience,
+							ExpirationSeconds: &expirationSeconds,
+							Path:              "token",
+						},
+					},
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "kube-root-ca.crt",
+							},
+							Items: []corev1.KeyToPath{
+								{
+									Key:  "ca.crt",
+									Path: "ca.crt",
+								},
+							},
+						},
+					},
+					{
+						DownwardAPI: &corev1.DownwardAPIProjection{
+							Items: []corev1.DownwardAPIVolumeFile{
+								{
+									Path: "namespace",
+									FieldRef: &corev1.ObjectFieldSelector{
+										APIVersion: "v1",
+										FieldPath:  "metadata.namespace",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
-	return []string(nil)
 }
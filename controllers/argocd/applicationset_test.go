@@ -16,9 +16,15 @@ package argocd
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -29,7 +35,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -91,7 +101,7 @@ func TestReconcileApplicationSet_CreateDeployments(t *testing.T) {
 
 	sa := corev1.ServiceAccount{}
 
-	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
 
 	deployment := &appsv1.Deployment{}
 	assert.NoError(t, r.Client.Get(
@@ -110,7 +120,9 @@ func checkExpectedDeploymentValues(t *testing.T, r *ReconcileArgoCD, deployment
 	assert.Equal(t, deployment.Spec.Template.Spec.ServiceAccountName, sa.ObjectMeta.Name)
 	appsetAssertExpectedLabels(t, &deployment.ObjectMeta)
 
-	want := []corev1.Container{r.applicationSetContainer(a, false)}
+	container, err := r.applicationSetContainer(a, false, false)
+	assert.NoError(t, err)
+	want := []corev1.Container{container}
 
 	if diff := cmp.Diff(want, deployment.Spec.Template.Spec.Containers); diff != "" {
 		t.Fatalf("failed to reconcile applicationset-controller deployment containers:\n%s", diff)
@@ -207,7 +219,7 @@ func TestReconcileApplicationSetProxyConfiguration(t *testing.T) {
 
 	sa := corev1.ServiceAccount{}
 
-	r.reconcileApplicationSetDeployment(a, &sa)
+	r.reconcileApplicationSetDeployment(context.TODO(), a, &sa)
 
 	want := []corev1.EnvVar{
 		{
@@ -282,7 +294,7 @@ func TestReconcileApplicationSet_UpdateExistingDeployments(t *testing.T) {
 
 	sa := corev1.ServiceAccount{}
 
-	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
 
 	deployment := &appsv1.Deployment{}
 	assert.NoError(t, r.Client.Get(
@@ -298,6 +310,295 @@ func TestReconcileApplicationSet_UpdateExistingDeployments(t *testing.T) {
 
 }
 
+func TestReconcileApplicationSet_Deployments_SidecarContainers(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	sidecar := corev1.Container{
+		Name:  "log-forwarder",
+		Image: "quay.io/example/log-forwarder:latest",
+	}
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SidecarContainers: []corev1.Container{sidecar},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 2)
+	// the applicationset controller container must remain the first container
+	assert.Equal(t, "argocd-applicationset-controller", containers[0].Name)
+	assert.Equal(t, sidecar, containers[1])
+
+	// removing the sidecar from the spec should remove it from the deployment
+	a.Spec.ApplicationSet.SidecarContainers = nil
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, "argocd-applicationset-controller", deployment.Spec.Template.Spec.Containers[0].Name)
+}
+
+func TestReconcileApplicationSet_Deployments_ImagePullPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	// pull policy should default to Always
+	assert.Equal(t, corev1.PullAlways, deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy)
+
+	// an override in the spec should be applied to the existing deployment
+	a.Spec.ApplicationSet.ImagePullPolicy = corev1.PullIfNotPresent
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	assert.Equal(t, corev1.PullIfNotPresent, deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy)
+}
+
+func TestReconcileApplicationSet_Deployments_Probes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+
+	// probes should use the defaults, readiness checks the webhook port and liveness the probe address of the manager
+	for probe, port := range map[*corev1.Probe]int{
+		deployment.Spec.Template.Spec.Containers[0].ReadinessProbe: 7000,
+		deployment.Spec.Template.Spec.Containers[0].LivenessProbe:  8081,
+	} {
+		assert.NotNil(t, probe)
+		assert.Nil(t, probe.HTTPGet)
+		assert.Equal(t, intstr.FromInt(port), probe.TCPSocket.Port)
+		assert.Equal(t, int32(5), probe.InitialDelaySeconds)
+		assert.Equal(t, int32(10), probe.PeriodSeconds)
+		assert.Equal(t, int32(3), probe.FailureThreshold)
+	}
+
+	// overrides in the spec should be applied to the existing deployment
+	initialDelay, failureThreshold := int32(30), int32(6)
+	a.Spec.ApplicationSet.LivenessProbe = &argoproj.ArgoCDProbeSpec{
+		InitialDelaySeconds: &initialDelay,
+		FailureThreshold:    &failureThreshold,
+	}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+
+	liveness := deployment.Spec.Template.Spec.Containers[0].LivenessProbe
+	assert.Equal(t, int32(30), liveness.InitialDelaySeconds)
+	assert.Equal(t, int32(6), liveness.FailureThreshold)
+	assert.Equal(t, int32(10), liveness.PeriodSeconds)
+	assert.Equal(t, int32(5), deployment.Spec.Template.Spec.Containers[0].ReadinessProbe.InitialDelaySeconds)
+
+	// a probe removed out of band should be restored
+	deployment.Spec.Template.Spec.Containers[0].ReadinessProbe = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.NotNil(t, deployment.Spec.Template.Spec.Containers[0].ReadinessProbe)
+}
+
+func TestReconcileApplicationSet_Deployments_HostAliases(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	a.Spec.HostAliases = []corev1.HostAlias{
+		{IP: "10.0.0.10", Hostnames: []string{"gitlab.internal"}},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, a.Spec.HostAliases, deployment.Spec.Template.Spec.HostAliases)
+
+	// changes to the host aliases should be applied to the existing deployment
+	a.Spec.HostAliases = append(a.Spec.HostAliases, corev1.HostAlias{IP: "10.0.0.11", Hostnames: []string{"github.internal"}})
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, a.Spec.HostAliases, deployment.Spec.Template.Spec.HostAliases)
+
+	// removing the host aliases should remove them from the deployment
+	a.Spec.HostAliases = nil
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Empty(t, deployment.Spec.Template.Spec.HostAliases)
+}
+
+func TestReconcileApplicationSet_Deployments_Volumes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		Volumes: []corev1.Volume{
+			{
+				Name: "tmp",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+				},
+			},
+			{
+				Name: "plugin-config",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "plugin-config"},
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "tmp", MountPath: "/tmp"},
+			{Name: "plugin-config", MountPath: "/app/config/plugin"},
+		},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	// the conflicting tmp volume replaces the operator one in place, the other volume is appended
+	volumes := deployment.Spec.Template.Spec.Volumes
+	volumeNames := []string{}
+	for _, v := range volumes {
+		volumeNames = append(volumeNames, v.Name)
+	}
+	assert.Equal(t, []string{"ssh-known-hosts", "tls-certs", "gpg-keys", "gpg-keyring", "tmp", "plugin-config"}, volumeNames)
+	assert.Equal(t, corev1.StorageMediumMemory, volumes[4].EmptyDir.Medium)
+
+	mountPaths := []string{}
+	for _, m := range deployment.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mountPaths = append(mountPaths, m.MountPath)
+	}
+	assert.Equal(t, []string{"/app/config/ssh", "/app/config/tls", "/app/config/gpg/source", "/app/config/gpg/keys", "/tmp", "/app/config/plugin"}, mountPaths)
+}
+
+func TestReconcileApplicationSet_Deployments_DNS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Empty(t, deployment.Spec.Template.Spec.DNSPolicy)
+	assert.Nil(t, deployment.Spec.Template.Spec.DNSConfig)
+
+	// a custom DNS policy and config should be applied to the existing deployment
+	a.Spec.DNSPolicy = corev1.DNSNone
+	a.Spec.DNSConfig = &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.53"}}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, corev1.DNSNone, deployment.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, a.Spec.DNSConfig, deployment.Spec.Template.Spec.DNSConfig)
+
+	// removing them should restore the cluster default
+	a.Spec.DNSPolicy = ""
+	a.Spec.DNSConfig = nil
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Empty(t, deployment.Spec.Template.Spec.DNSPolicy)
+	assert.Nil(t, deployment.Spec.Template.Spec.DNSConfig)
+}
+
 func TestReconcileApplicationSet_Deployments_resourceRequirements(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCDWithResources()
@@ -311,7 +612,7 @@ func TestReconcileApplicationSet_Deployments_resourceRequirements(t *testing.T)
 
 	sa := corev1.ServiceAccount{}
 
-	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
 
 	deployment := &appsv1.Deployment{}
 	assert.NoError(t, r.Client.Get(
@@ -325,7 +626,9 @@ func TestReconcileApplicationSet_Deployments_resourceRequirements(t *testing.T)
 	assert.Equal(t, deployment.Spec.Template.Spec.ServiceAccountName, sa.ObjectMeta.Name)
 	appsetAssertExpectedLabels(t, &deployment.ObjectMeta)
 
-	containerWant := []corev1.Container{r.applicationSetContainer(a, false)}
+	container, err := r.applicationSetContainer(a, false, false)
+	assert.NoError(t, err)
+	containerWant := []corev1.Container{container}
 
 	if diff := cmp.Diff(containerWant, deployment.Spec.Template.Spec.Containers); diff != "" {
 		t.Fatalf("failed to reconcile argocd-server deployment:\n%s", diff)
@@ -414,7 +717,7 @@ func TestReconcileApplicationSet_Deployments_SpecOverride(t *testing.T) {
 			a.Spec.ApplicationSet = test.appSetField
 
 			sa := corev1.ServiceAccount{}
-			assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+			assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
 
 			deployment := &appsv1.Deployment{}
 			assert.NoError(t, r.Client.Get(
@@ -436,6 +739,8 @@ func TestReconcileApplicationSet_Deployments_SpecOverride(t *testing.T) {
 func TestReconcileApplicationSet_Deployments_Command(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
+	singleReplica, multipleReplicas := int32(1), int32(2)
+
 	tests := []struct {
 		name           string
 		argocdSpec     argoproj.ArgoCDSpec
@@ -475,86 +780,432 @@ func TestReconcileApplicationSet_Deployments_Command(t *testing.T) {
 			expectedCmd:    []string{"--allowed-scm-providers", "github.com"},
 			notExpectedCmd: []string{"--applicationset-namespaces", "foo"},
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-
-			a := makeTestArgoCD()
-			ns1 := v1.Namespace{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "foo",
+		{
+			name: "SCM providers explicitly disabled",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					DisableSCMProviders: boolPtr(true),
 				},
-			}
-			ns2 := v1.Namespace{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "bar",
+			},
+			expectedCmd: []string{"--enable-scm-providers=false"},
+		},
+		{
+			name: "SCM providers explicitly enabled with appset in any namespaces and SCM provider list",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					SourceNamespaces:    []string{"foo", "bar"},
+					SCMProviders:        []string{"github.com"},
+					DisableSCMProviders: boolPtr(false),
 				},
-			}
-			resObjs := []client.Object{a, &ns1, &ns2}
-			subresObjs := []client.Object{a}
-			runtimeObjs := []runtime.Object{}
-			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
-			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
-			r := makeTestReconciler(cl, sch)
-			cm := newConfigMapWithName(getCAConfigMapName(a), a)
-			r.Client.Create(context.Background(), cm, &client.CreateOptions{})
-
-			a.Spec = test.argocdSpec
-
-			sa := corev1.ServiceAccount{}
-			assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
-
-			deployment := &appsv1.Deployment{}
-			assert.NoError(t, r.Client.Get(
-				context.TODO(),
-				types.NamespacedName{
-					Name:      "argocd-applicationset-controller",
-					Namespace: a.Namespace,
+				SourceNamespaces: []string{"foo", "bar"},
+			},
+			expectedCmd:    []string{"--applicationset-namespaces", "foo,bar", "--allowed-scm-providers", "github.com"},
+			notExpectedCmd: []string{"--enable-scm-providers=false"},
+		},
+		{
+			// the controller does not start without one of both flags
+			name: "SCM providers explicitly enabled with appset in any namespaces without SCM provider list",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					SourceNamespaces:    []string{"foo", "bar"},
+					DisableSCMProviders: boolPtr(false),
 				},
-				deployment))
-
-			cmds := deployment.Spec.Template.Spec.Containers[0].Command
-			for _, c := range test.expectedCmd {
-				assert.True(t, contains(cmds, c))
-			}
-			for _, c := range test.notExpectedCmd {
-				assert.False(t, contains(cmds, c))
-			}
-		})
-	}
-}
-
-func TestReconcileApplicationSet_ServiceAccount(t *testing.T) {
-	logf.SetLogger(ZapLogger(true))
-	a := makeTestArgoCD()
-	resObjs := []client.Object{a}
-	subresObjs := []client.Object{a}
-	runtimeObjs := []runtime.Object{}
-	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
-	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
-	r := makeTestReconciler(cl, sch)
-
-	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
-		Enabled: boolPtr(true),
-	}
-
-	retSa, err := r.reconcileApplicationSetServiceAccount(a)
-	assert.NoError(t, err)
-
-	sa := &corev1.ServiceAccount{}
-	assert.NoError(t, r.Client.Get(
-		context.TODO(),
-		types.NamespacedName{
-			Name:      "argocd-applicationset-controller",
-			Namespace: a.Namespace,
+				SourceNamespaces: []string{"foo", "bar"},
+			},
+			expectedCmd: []string{"--applicationset-namespaces", "foo,bar", "--enable-scm-providers=false"},
 		},
-		sa))
-
-	assert.Equal(t, sa.Name, retSa.Name)
-
-	appsetAssertExpectedLabels(t, &sa.ObjectMeta)
-}
+		{
+			name: "SCM providers enabled by default without appset in any namespaces",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{},
+			},
+			notExpectedCmd: []string{"--enable-scm-providers=false"},
+		},
+		{
+			name: "leader election with multiple replicas",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					Replicas: &multipleReplicas,
+				},
+			},
+			expectedCmd: []string{"--enable-leader-election"},
+		},
+		{
+			name: "no leader election with a single replica",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					Replicas: &singleReplica,
+				},
+			},
+			notExpectedCmd: []string{"--enable-leader-election"},
+		},
+		{
+			name: "leader election with autoscaling",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					Replicas: &singleReplica,
+					Autoscale: &argoproj.ArgoCDApplicationSetAutoscaleSpec{
+						Enabled: true,
+					},
+				},
+			},
+			expectedCmd: []string{"--enable-leader-election"},
+			// the controller has no flags for the leader election timings
+			notExpectedCmd: []string{"--leader-election-lease-duration", "--leader-election-renew-deadline", "--leader-election-retry-period"},
+		},
+		{
+			name: "with git timeout",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					GitTimeout: &metav1.Duration{Duration: 2*time.Minute + 500*time.Millisecond},
+				},
+			},
+			expectedCmd: []string{"--repo-server-timeout-seconds", "121"},
+		},
+		{
+			name: "without git timeout",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{},
+			},
+			notExpectedCmd: []string{"--repo-server-timeout-seconds"},
+		},
+		{
+			name: "with sync policy",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					Policy: "sync",
+				},
+			},
+			expectedCmd: []string{"--policy", "sync"},
+		},
+		{
+			name: "with create-only policy",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					Policy: "create-only",
+				},
+			},
+			expectedCmd: []string{"--policy", "create-only"},
+		},
+		{
+			name: "with create-update policy",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					Policy: "create-update",
+				},
+			},
+			expectedCmd: []string{"--policy", "create-update"},
+		},
+		{
+			name: "with create-delete policy",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					Policy: "create-delete",
+				},
+			},
+			expectedCmd: []string{"--policy", "create-delete"},
+		},
+		{
+			name: "with invalid policy",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					Policy: "delete-only",
+				},
+			},
+			notExpectedCmd: []string{"--policy"},
+		},
+		{
+			name: "without policy",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{},
+			},
+			notExpectedCmd: []string{"--policy"},
+		},
+		{
+			name: "with preserved annotations",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{
+					PreservedAnnotations: []string{"example.com/owner", "notifications.argoproj.io/subscribe"},
+				},
+			},
+			expectedCmd: []string{"--preserved-annotations", "example.com/owner,notifications.argoproj.io/subscribe"},
+		},
+		{
+			name: "without preserved annotations",
+			argocdSpec: argoproj.ArgoCDSpec{
+				ApplicationSet: &argoproj.ArgoCDApplicationSet{},
+			},
+			notExpectedCmd: []string{"--preserved-annotations"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			a := makeTestArgoCD()
+			ns1 := v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			}
+			ns2 := v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "bar",
+				},
+			}
+			resObjs := []client.Object{a, &ns1, &ns2}
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+			cm := newConfigMapWithName(getCAConfigMapName(a), a)
+			r.Client.Create(context.Background(), cm, &client.CreateOptions{})
+
+			a.Spec = test.argocdSpec
+
+			sa := corev1.ServiceAccount{}
+			assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+
+			deployment := &appsv1.Deployment{}
+			assert.NoError(t, r.Client.Get(
+				context.TODO(),
+				types.NamespacedName{
+					Name:      "argocd-applicationset-controller",
+					Namespace: a.Namespace,
+				},
+				deployment))
+
+			cmds := deployment.Spec.Template.Spec.Containers[0].Command
+			for _, c := range test.expectedCmd {
+				assert.True(t, contains(cmds, c))
+			}
+			for _, c := range test.notExpectedCmd {
+				assert.False(t, contains(cmds, c))
+			}
+		})
+	}
+}
+
+func TestReconcileApplicationSet_Deployments_Autoscale(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	replicas := int32(2)
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		Replicas:  &replicas,
+		Autoscale: &argoproj.ArgoCDApplicationSetAutoscaleSpec{Enabled: true},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+
+	// the replicas of the spec are ignored and leader election is enabled
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Nil(t, deployment.Spec.Replicas)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-leader-election")
+
+	// the replicas set by the autoscaler are kept
+	scaled := int32(4)
+	deployment.Spec.Replicas = &scaled
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, scaled, *deployment.Spec.Replicas)
+
+	// disabling autoscaling restores the replicas of the spec
+	a.Spec.ApplicationSet.Autoscale.Enabled = false
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, replicas, *deployment.Spec.Replicas)
+}
+
+func TestReconcileApplicationSet_Deployments_SourceNamespacesListError(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.SourceNamespaces = []string{"foo"}
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SourceNamespaces: []string{"foo"},
+	}
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*corev1.NamespaceList); ok {
+					return errors.New("namespace list failed")
+				}
+				return client.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+	err := r.reconcileApplicationSetDeployment(context.TODO(), a, &sa)
+	assert.ErrorContains(t, err, "namespace list failed")
+
+	// the deployment must not be created without the applicationset namespaces flag
+	deployment := &appsv1.Deployment{}
+	err = r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileApplicationSet_ServiceAccount(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		Enabled: boolPtr(true),
+	}
+
+	retSa, err := r.reconcileApplicationSetServiceAccount(context.TODO(), a)
+	assert.NoError(t, err)
+
+	sa := &corev1.ServiceAccount{}
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		sa))
+
+	assert.Equal(t, sa.Name, retSa.Name)
+
+	appsetAssertExpectedLabels(t, &sa.ObjectMeta)
+}
+
+func TestReconcileApplicationSet_ServiceAccountAnnotations(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	roleARN := "arn:aws:iam::111122223333:role/appset"
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		ServiceAccountAnnotations: map[string]string{
+			"eks.amazonaws.com/role-arn": roleARN,
+		},
+	}
+
+	_, err := r.reconcileApplicationSetServiceAccount(context.TODO(), a)
+	assert.NoError(t, err)
+
+	sa := &corev1.ServiceAccount{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, sa))
+	assert.Equal(t, roleARN, sa.Annotations["eks.amazonaws.com/role-arn"])
+
+	// annotation removed out of band should be reconciled back
+	delete(sa.Annotations, "eks.amazonaws.com/role-arn")
+	assert.NoError(t, r.Client.Update(context.TODO(), sa))
+
+	_, err = r.reconcileApplicationSetServiceAccount(context.TODO(), a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), key, sa))
+	assert.Equal(t, roleARN, sa.Annotations["eks.amazonaws.com/role-arn"])
+}
+
+func TestReconcileApplicationSet_SCMRootCAConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SCMRootCAConfigMap: "my-scm-ca",
+	}
+	source := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-scm-ca",
+			Namespace: a.Namespace,
+		},
+		Data: map[string]string{
+			"cert": "ca-data-1",
+		},
+	}
+
+	resObjs := []client.Object{a, source}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationSetSCMRootCAConfigMap(context.TODO(), a))
+
+	target := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, target))
+	assert.Equal(t, map[string]string{"cert": "ca-data-1"}, target.Data)
+	assert.Len(t, target.OwnerReferences, 1)
+
+	// changes to the source configmap should be propagated
+	source.Data["cert"] = "ca-data-2"
+	assert.NoError(t, r.Client.Update(context.TODO(), source))
+	assert.NoError(t, r.reconcileApplicationSetSCMRootCAConfigMap(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, target))
+	assert.Equal(t, map[string]string{"cert": "ca-data-2"}, target.Data)
+
+	// a missing source configmap is not an error
+	a.Spec.ApplicationSet.SCMRootCAConfigMap = "missing"
+	assert.NoError(t, r.reconcileApplicationSetSCMRootCAConfigMap(context.TODO(), a))
+
+	// the copy is deleted once the source configmap is no longer set
+	a.Spec.ApplicationSet.SCMRootCAConfigMap = ""
+	assert.NoError(t, r.reconcileApplicationSetSCMRootCAConfigMap(context.TODO(), a))
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(context.TODO(), key, target)))
+}
+
+func TestReconcileApplicationSet_SCMRootCAConfigMap_userCreatedKept(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	userCreated := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName,
+			Namespace: a.Namespace,
+		},
+		Data: map[string]string{
+			"cert": "ca-data",
+		},
+	}
+
+	resObjs := []client.Object{a, userCreated}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationSetSCMRootCAConfigMap(context.TODO(), a))
+	key := types.NamespacedName{Name: common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, &corev1.ConfigMap{}))
+}
 
 // Test creation/cleanup of applicationset-controller clusterrole & clusterrolebinding
 func TestReconcileApplicationSet_ClusterRBACCreationAndCleanup(t *testing.T) {
@@ -577,9 +1228,9 @@ func TestReconcileApplicationSet_ClusterRBACCreationAndCleanup(t *testing.T) {
 	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa-name"}}
 
 	// test: ArgoCD is not cluster-scoped, resources shouldn't be created
-	role, err := r.reconcileApplicationSetClusterRole(a)
+	role, err := r.reconcileApplicationSetClusterRole(context.TODO(), a)
 	assert.NoError(t, err)
-	err = r.reconcileApplicationSetClusterRoleBinding(a, role, sa)
+	err = r.reconcileApplicationSetClusterRoleBinding(context.TODO(), a, role, sa)
 	assert.NoError(t, err)
 
 	// clusterrole should not be created
@@ -597,9 +1248,9 @@ func TestReconcileApplicationSet_ClusterRBACCreationAndCleanup(t *testing.T) {
 	// test: make ArgoCD cluster-scoped, resources should be created
 	os.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", a.Namespace)
 
-	role, err = r.reconcileApplicationSetClusterRole(a)
+	role, err = r.reconcileApplicationSetClusterRole(context.TODO(), a)
 	assert.NoError(t, err)
-	err = r.reconcileApplicationSetClusterRoleBinding(a, role, sa)
+	err = r.reconcileApplicationSetClusterRoleBinding(context.TODO(), a, role, sa)
 	assert.NoError(t, err)
 
 	// clusterrole should be created
@@ -616,9 +1267,9 @@ func TestReconcileApplicationSet_ClusterRBACCreationAndCleanup(t *testing.T) {
 
 	// test: make ArgoCD namespaced-scope, existing resources should be deleted
 	os.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", "")
-	role, err = r.reconcileApplicationSetClusterRole(a)
+	role, err = r.reconcileApplicationSetClusterRole(context.TODO(), a)
 	assert.NoError(t, err)
-	err = r.reconcileApplicationSetClusterRoleBinding(a, role, sa)
+	err = r.reconcileApplicationSetClusterRoleBinding(context.TODO(), a, role, sa)
 	assert.NoError(t, err)
 
 	// clusterrole should not exists
@@ -634,6 +1285,114 @@ func TestReconcileApplicationSet_ClusterRBACCreationAndCleanup(t *testing.T) {
 	assert.True(t, apierrors.IsNotFound(err))
 }
 
+func TestReconcileApplicationSet_ClusterRBACDisabled(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resName := "argocd-argocd-argocd-applicationset-controller"
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa-name"}}
+	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", a.Namespace)
+
+	// cluster-scoped ArgoCD, resources are created
+	role, err := r.reconcileApplicationSetClusterRole(context.TODO(), a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileApplicationSetClusterRoleBinding(context.TODO(), a, role, sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), cntrlClient.ObjectKey{Name: resName}, &rbacv1.ClusterRole{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), cntrlClient.ObjectKey{Name: resName}, &rbacv1.ClusterRoleBinding{}))
+
+	// cluster roles disabled, existing resources are deleted and not recreated
+	t.Setenv(common.ArgoCDAppSetDisableClusterRolesEnvName, "true")
+	for i := 0; i < 2; i++ {
+		role, err = r.reconcileApplicationSetClusterRole(context.TODO(), a)
+		assert.NoError(t, err)
+		assert.NoError(t, r.reconcileApplicationSetClusterRoleBinding(context.TODO(), a, role, sa))
+
+		err = r.Client.Get(context.TODO(), cntrlClient.ObjectKey{Name: resName}, &rbacv1.ClusterRole{})
+		assert.True(t, apierrors.IsNotFound(err))
+		err = r.Client.Get(context.TODO(), cntrlClient.ObjectKey{Name: resName}, &rbacv1.ClusterRoleBinding{})
+		assert.True(t, apierrors.IsNotFound(err))
+	}
+}
+
+func TestIsApplicationSetClusterRoleDisabled(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "", want: false},
+		{value: "true", want: true},
+		{value: "false", want: false},
+		{value: "invalid", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Setenv(common.ArgoCDAppSetDisableClusterRolesEnvName, test.value)
+			assert.Equal(t, test.want, isApplicationSetClusterRoleDisabled())
+		})
+	}
+}
+
+// Test that cleanup of applicationset-controller cluster RBAC skips resources of another instance with a colliding name
+func TestReconcileApplicationSet_ClusterRBACCleanupNameCollision(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	// "argocd" in namespace "x-foo" and "argocd-x" in namespace "foo" generate the same cluster resource names
+	owner := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Namespace = "x-foo"
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	})
+	other := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "argocd-x"
+		a.Namespace = "foo"
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	})
+	resName := "argocd-x-foo-argocd-applicationset-controller"
+	assert.Equal(t, GenerateUniqueResourceName(common.ArgoCDApplicationSetControllerComponent, owner), GenerateUniqueResourceName(common.ArgoCDApplicationSetControllerComponent, other))
+
+	resObjs := []client.Object{owner, other}
+	subresObjs := []client.Object{owner, other}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa-name"}}
+
+	// owner is cluster-scoped, resources should be created
+	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", owner.Namespace)
+	role, err := r.reconcileApplicationSetClusterRole(context.TODO(), owner)
+	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileApplicationSetClusterRoleBinding(context.TODO(), owner, role, sa))
+
+	// other is namespace-scoped, it must not delete the resources of owner
+	role, err = r.reconcileApplicationSetClusterRole(context.TODO(), other)
+	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileApplicationSetClusterRoleBinding(context.TODO(), other, role, sa))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), cntrlClient.ObjectKey{Name: resName}, &rbacv1.ClusterRole{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), cntrlClient.ObjectKey{Name: resName}, &rbacv1.ClusterRoleBinding{}))
+
+	// owner becomes namespace-scoped, its resources should be deleted
+	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", "")
+	role, err = r.reconcileApplicationSetClusterRole(context.TODO(), owner)
+	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileApplicationSetClusterRoleBinding(context.TODO(), owner, role, sa))
+
+	err = r.Client.Get(context.TODO(), cntrlClient.ObjectKey{Name: resName}, &rbacv1.ClusterRole{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), cntrlClient.ObjectKey{Name: resName}, &rbacv1.ClusterRoleBinding{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
 // Test creation/cleanup of applicationset-controller role & rolebinding in source namespaces
 // Appset resources are only created if target source ns is subset of apps source namespaces
 func TestReconcileApplicationSet_SourceNamespacesRBACCreation(t *testing.T) {
@@ -708,7 +1467,7 @@ func TestReconcileApplicationSet_SourceNamespacesRBACCreation(t *testing.T) {
 				createNamespace(r, ns, "")
 			}
 
-			err := r.reconcileApplicationSetSourceNamespacesResources(a)
+			err := r.reconcileApplicationSetSourceNamespacesResources(context.TODO(), a)
 			if test.expectErr {
 				assert.Error(t, err)
 			}
@@ -760,13 +1519,398 @@ func TestReconcileApplicationSet_SourceNamespacesRBACCreation(t *testing.T) {
 				assert.False(t, found)
 			}
 
-		})
+		})
+	}
+}
+
+func TestReconcileApplicationSet_SourceNamespacesStatus(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.SourceNamespaces = []string{"foo", "bar"}
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SourceNamespaces: []string{"foo", "bar", "baz"},
+	}
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithStatusSubresource(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*rbacv1.Role); ok && obj.GetNamespace() == "bar" {
+					return errors.New("role creation denied")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	for _, ns := range []string{"foo", "bar", "baz"} {
+		assert.NoError(t, createNamespace(r, ns, ""))
+	}
+
+	err := r.reconcileApplicationSetSourceNamespacesResources(context.TODO(), a)
+	assert.ErrorContains(t, err, "role creation denied")
+
+	argocd := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, argocd))
+
+	statuses := argocd.Status.ApplicationSetSourceNamespaces
+	assert.Len(t, statuses, 3)
+	assert.Equal(t, argoproj.ArgoCDApplicationSetSourceNamespaceStatus{
+		Name:  "foo",
+		State: argoproj.ApplicationSetSourceNamespaceReconciled,
+	}, statuses[0])
+	assert.Equal(t, "bar", statuses[1].Name)
+	assert.Equal(t, argoproj.ApplicationSetSourceNamespaceFailed, statuses[1].State)
+	assert.Contains(t, statuses[1].Message, "role creation denied")
+	assert.Equal(t, "baz", statuses[2].Name)
+	assert.Equal(t, argoproj.ApplicationSetSourceNamespaceSkipped, statuses[2].State)
+
+	// disabling the applicationset controller should clear the status
+	a.Spec.ApplicationSet = nil
+	assert.NoError(t, r.reconcileApplicationSetSourceNamespacesResources(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, argocd))
+	assert.Empty(t, argocd.Status.ApplicationSetSourceNamespaces)
+}
+
+func TestReconcileApplicationSet_SourceNamespacesConcurrency(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv(common.ArgoCDAppSetSourceNamespacesConcurrencyEnvName, "3")
+
+	var sourceNamespaces []string
+	for i := 0; i < 12; i++ {
+		sourceNamespaces = append(sourceNamespaces, fmt.Sprintf("ns-%d", i))
+	}
+	a := makeTestArgoCD()
+	a.Spec.SourceNamespaces = sourceNamespaces
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SourceNamespaces: sourceNamespaces,
+	}
+
+	var inFlight, maxInFlight int32
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithStatusSubresource(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*rbacv1.Role); ok {
+					current := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						observed := atomic.LoadInt32(&maxInFlight)
+						if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					if obj.GetNamespace() == "ns-4" || obj.GetNamespace() == "ns-7" {
+						return fmt.Errorf("role creation denied in %s", obj.GetNamespace())
+					}
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	for _, ns := range sourceNamespaces {
+		assert.NoError(t, createNamespace(r, ns, ""))
+	}
+
+	err := r.reconcileApplicationSetSourceNamespacesResources(context.TODO(), a)
+	assert.ErrorContains(t, err, "role creation denied in ns-4")
+	assert.ErrorContains(t, err, "role creation denied in ns-7")
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(3))
+
+	argocd := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, argocd))
+
+	statuses := argocd.Status.ApplicationSetSourceNamespaces
+	assert.Len(t, statuses, len(sourceNamespaces))
+	for i, status := range statuses {
+		// statuses are recorded in spec order regardless of completion order
+		assert.Equal(t, sourceNamespaces[i], status.Name)
+		if status.Name == "ns-4" || status.Name == "ns-7" {
+			assert.Equal(t, argoproj.ApplicationSetSourceNamespaceFailed, status.State)
+		} else {
+			assert.Equal(t, argoproj.ApplicationSetSourceNamespaceReconciled, status.State)
+		}
+		assert.Contains(t, r.ManagedApplicationSetSourceNamespaces, status.Name)
+	}
+}
+
+func TestReconcileApplicationSetController_CancelledContext(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	start := time.Now()
+	err := r.reconcileApplicationSetController(ctx, a)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// no resources should have been created
+	sa := &corev1.ServiceAccount{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}, sa)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileApplicationSetController_CancelledDuringReconcile(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client ignores the context, fail like the API client does once it is done
+			Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return client.Get(ctx, key, obj, opts...)
+			},
+			// cancel the reconcile once the role binding is created
+			Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*rbacv1.RoleBinding); ok {
+					defer cancel()
+				}
+				return client.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	err := r.reconcileApplicationSetController(ctx, a)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// the reads of the following steps are cancelled, so the deployment is not created
+	deployment := &appsv1.Deployment{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}, deployment)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestGetApplicationSetSourceNamespacesConcurrency(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{name: "unset", value: "", want: common.ArgoCDDefaultAppSetSourceNamespacesConcurrency},
+		{name: "valid", value: "10", want: 10},
+		{name: "zero", value: "0", want: common.ArgoCDDefaultAppSetSourceNamespacesConcurrency},
+		{name: "invalid", value: "many", want: common.ArgoCDDefaultAppSetSourceNamespacesConcurrency},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(common.ArgoCDAppSetSourceNamespacesConcurrencyEnvName, test.value)
+			assert.Equal(t, test.want, getApplicationSetSourceNamespacesConcurrency())
+		})
+	}
+}
+
+func TestReconcileApplicationSet_Role(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		Enabled: boolPtr(true),
+	}
+
+	roleRet, err := r.reconcileApplicationSetRole(context.TODO(), a)
+	assert.NoError(t, err)
+
+	role := &rbacv1.Role{}
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		role))
+
+	assert.Equal(t, roleRet.Name, role.Name)
+	appsetAssertExpectedLabels(t, &role.ObjectMeta)
+
+	expectedResources := []string{
+		"deployments",
+		"secrets",
+		"configmaps",
+		"events",
+		"applicationsets/status",
+		"applications",
+		"applicationsets",
+		"appprojects",
+		"applicationsets/finalizers",
+		"leases",
+	}
+
+	foundResources := []string{}
+
+	for _, rule := range role.Rules {
+		for _, resource := range rule.Resources {
+			foundResources = append(foundResources, resource)
+		}
+	}
+
+	sort.Strings(expectedResources)
+	sort.Strings(foundResources)
+
+	assert.Equal(t, expectedResources, foundResources)
+}
+
+func TestValidateApplicationSetSourceNamespaces(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.SourceNamespaces = []string{"foo"}
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SourceNamespaces: []string{"foo", "bar"},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, "foo", ""))
+	assert.NoError(t, createNamespace(r, "bar", ""))
+
+	listWarnings := func() []corev1.Event {
+		events := &corev1.EventList{}
+		assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+		return events.Items
+	}
+
+	assert.NoError(t, r.validateApplicationSetSourceNamespaces(a))
+	events := listWarnings()
+	assert.Len(t, events, 1)
+	assert.Equal(t, corev1.EventTypeWarning, events[0].Type)
+	assert.Equal(t, "InvalidApplicationSetSourceNamespaces", events[0].Reason)
+	assert.Contains(t, events[0].Message, "[bar]")
+	assert.Equal(t, a.Name, events[0].InvolvedObject.Name)
+
+	// the same warning is not emitted twice
+	assert.NoError(t, r.validateApplicationSetSourceNamespaces(a))
+	assert.Len(t, listWarnings(), 1)
+
+	// fixing the configuration clears the warning
+	a.Spec.ApplicationSet.SourceNamespaces = []string{"foo"}
+	assert.NoError(t, r.validateApplicationSetSourceNamespaces(a))
+	assert.Len(t, listWarnings(), 1)
+	assert.Empty(t, r.warningEvents[types.NamespacedName{Name: a.Name, Namespace: a.Namespace}])
+}
+
+func TestValidateApplicationSetPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		Policy: "delete-only",
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	listWarnings := func() []corev1.Event {
+		events := &corev1.EventList{}
+		assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+		return events.Items
+	}
+
+	assert.NoError(t, r.validateApplicationSetPolicy(a))
+	events := listWarnings()
+	assert.Len(t, events, 1)
+	assert.Equal(t, corev1.EventTypeWarning, events[0].Type)
+	assert.Equal(t, "InvalidApplicationSetPolicy", events[0].Reason)
+	assert.Contains(t, events[0].Message, `"delete-only"`)
+
+	// the same warning is not emitted twice
+	assert.NoError(t, r.validateApplicationSetPolicy(a))
+	assert.Len(t, listWarnings(), 1)
+
+	// fixing the configuration clears the warning
+	a.Spec.ApplicationSet.Policy = "create-only"
+	assert.NoError(t, r.validateApplicationSetPolicy(a))
+	assert.Len(t, listWarnings(), 1)
+	assert.Empty(t, r.warningEvents[types.NamespacedName{Name: a.Name, Namespace: a.Namespace}])
+}
+
+func TestValidateApplicationSetSCMProviders(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.SourceNamespaces = []string{"foo"}
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SourceNamespaces:    []string{"foo"},
+		DisableSCMProviders: boolPtr(false),
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, "foo", ""))
+
+	listWarnings := func() []corev1.Event {
+		events := &corev1.EventList{}
+		assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+		return events.Items
 	}
+
+	assert.NoError(t, r.validateApplicationSetSCMProviders(a))
+	events := listWarnings()
+	assert.Len(t, events, 1)
+	assert.Equal(t, corev1.EventTypeWarning, events[0].Type)
+	assert.Equal(t, "InvalidApplicationSetSCMProviders", events[0].Reason)
+
+	// the same warning is not emitted twice
+	assert.NoError(t, r.validateApplicationSetSCMProviders(a))
+	assert.Len(t, listWarnings(), 1)
+
+	// an SCM provider allow list clears the warning
+	a.Spec.ApplicationSet.SCMProviders = []string{"github.com"}
+	assert.NoError(t, r.validateApplicationSetSCMProviders(a))
+	assert.Len(t, listWarnings(), 1)
+	assert.Empty(t, r.warningEvents[types.NamespacedName{Name: a.Name, Namespace: a.Namespace}])
 }
 
-func TestReconcileApplicationSet_Role(t *testing.T) {
+func TestValidateApplicationSetRepoServer(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	a.Spec.Repo.Enabled = boolPtr(false)
 
 	resObjs := []client.Object{a}
 	subresObjs := []client.Object{a}
@@ -775,57 +1919,99 @@ func TestReconcileApplicationSet_Role(t *testing.T) {
 	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
 	r := makeTestReconciler(cl, sch)
 
-	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
-		Enabled: boolPtr(true),
+	listWarnings := func() []corev1.Event {
+		events := &corev1.EventList{}
+		assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+		return events.Items
 	}
 
-	roleRet, err := r.reconcileApplicationSetRole(a)
-	assert.NoError(t, err)
+	assert.NoError(t, r.validateApplicationSet(a))
+	events := listWarnings()
+	assert.Len(t, events, 1)
+	assert.Equal(t, corev1.EventTypeWarning, events[0].Type)
+	assert.Equal(t, "ApplicationSetRepoServerDisabled", events[0].Reason)
+
+	// the same warning is not emitted twice
+	assert.NoError(t, r.validateApplicationSet(a))
+	assert.Len(t, listWarnings(), 1)
+
+	// no warning when the repo server is enabled
+	a.Spec.Repo.Enabled = boolPtr(true)
+	assert.NoError(t, r.validateApplicationSet(a))
+	assert.Len(t, listWarnings(), 1)
+
+	// the warning is emitted again if the repo server gets disabled again
+	a.Spec.Repo.Enabled = boolPtr(false)
+	assert.NoError(t, r.validateApplicationSet(a))
+	assert.Len(t, listWarnings(), 2)
+}
 
-	role := &rbacv1.Role{}
-	assert.NoError(t, r.Client.Get(
-		context.TODO(),
-		types.NamespacedName{
-			Name:      "argocd-applicationset-controller",
-			Namespace: a.Namespace,
-		},
-		role))
+func TestReconcileApplicationSet_SourceNamespaceRoleSecrets(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
 
-	assert.Equal(t, roleRet.Name, role.Name)
-	appsetAssertExpectedLabels(t, &role.ObjectMeta)
+	secretsRule := rbacv1.PolicyRule{
+		APIGroups: []string{""},
+		Resources: []string{"secrets"},
+		Verbs:     []string{"get", "list", "watch"},
+	}
 
-	expectedResources := []string{
-		"deployments",
-		"secrets",
-		"configmaps",
-		"events",
-		"applicationsets/status",
-		"applications",
-		"applicationsets",
-		"appprojects",
-		"applicationsets/finalizers",
-		"leases",
+	tests := []struct {
+		name                string
+		scmProviders        []string
+		disableSCMProviders *bool
+		wantSecrets         bool
+	}{
+		{name: "without SCM providers", wantSecrets: true},
+		{name: "with SCM providers", scmProviders: []string{"https://git.example.com/"}, wantSecrets: true},
+		{name: "with SCM providers disabled", disableSCMProviders: boolPtr(true), wantSecrets: false},
 	}
 
-	foundResources := []string{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD()
+			a.Spec.SourceNamespaces = []string{"foo"}
+			a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+				SourceNamespaces:    []string{"foo"},
+				SCMProviders:        test.scmProviders,
+				DisableSCMProviders: test.disableSCMProviders,
+			}
 
-	for _, rule := range role.Rules {
-		for _, resource := range rule.Resources {
-			foundResources = append(foundResources, resource)
-		}
-	}
+			resObjs := []client.Object{a}
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+			assert.NoError(t, createNamespace(r, "foo", ""))
 
-	sort.Strings(expectedResources)
-	sort.Strings(foundResources)
+			assert.NoError(t, r.reconcileApplicationSetSourceNamespacesResources(context.TODO(), a))
 
-	assert.Equal(t, expectedResources, foundResources)
+			role := &rbacv1.Role{}
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: getResourceNameForApplicationSetSourceNamespaces(a), Namespace: "foo"}, role))
+
+			secretRules := []rbacv1.PolicyRule{}
+			for _, rule := range role.Rules {
+				for _, resource := range rule.Resources {
+					if resource == "secrets" {
+						secretRules = append(secretRules, rule)
+					}
+				}
+			}
+			if test.wantSecrets {
+				assert.Equal(t, []rbacv1.PolicyRule{secretsRule}, secretRules)
+			} else {
+				assert.Empty(t, secretRules)
+			}
+		})
+	}
 }
 
 func TestReconcileApplicationSet_RoleBinding(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "role-name", Namespace: a.Namespace}}
 
-	resObjs := []client.Object{a}
+	resObjs := []client.Object{a, role}
 	subresObjs := []client.Object{a}
 	runtimeObjs := []runtime.Object{}
 	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
@@ -836,10 +2022,9 @@ func TestReconcileApplicationSet_RoleBinding(t *testing.T) {
 		Enabled: boolPtr(true),
 	}
 
-	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "role-name"}}
 	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa-name"}}
 
-	err := r.reconcileApplicationSetRoleBinding(a, role, sa)
+	err := r.reconcileApplicationSetRoleBinding(context.TODO(), a, role, sa)
 	assert.NoError(t, err)
 
 	roleBinding := &rbacv1.RoleBinding{}
@@ -858,6 +2043,159 @@ func TestReconcileApplicationSet_RoleBinding(t *testing.T) {
 
 }
 
+func TestReconcileApplicationSet_RoleBindingWithoutRole(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*rbacv1.Role); ok {
+					return errors.New("role create failed")
+				}
+				return client.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	assert.ErrorContains(t, r.reconcileApplicationSetController(context.TODO(), a), "role create failed")
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa-name", Namespace: a.Namespace}}
+	// a missing or empty role must not be referenced by the rolebinding
+	assert.Error(t, r.reconcileApplicationSetRoleBinding(context.TODO(), a, &rbacv1.Role{}, sa))
+	assert.Error(t, r.reconcileApplicationSetRoleBinding(context.TODO(), a, nil, sa))
+
+	roleBinding := &rbacv1.RoleBinding{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}, roleBinding)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileApplicationSet_SourceNamespaceLabelConflict(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	tests := []struct {
+		name        string
+		conflicts   int
+		wantUpdates int
+		wantState   string
+		wantLabel   bool
+	}{
+		{
+			name:        "conflicts resolved by a retry",
+			conflicts:   2,
+			wantUpdates: 3,
+			wantState:   argoproj.ApplicationSetSourceNamespaceReconciled,
+			wantLabel:   true,
+		},
+		{
+			name:        "persistent conflicts give up after a bounded number of retries",
+			conflicts:   100,
+			wantUpdates: retry.DefaultBackoff.Steps,
+			wantState:   argoproj.ApplicationSetSourceNamespaceFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD()
+			a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{SourceNamespaces: []string{"foo"}}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+
+			updates := 0
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := fake.NewClientBuilder().
+				WithScheme(sch).
+				WithObjects(a, ns).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							updates++
+							if updates <= test.conflicts {
+								return apierrors.NewConflict(corev1.Resource("namespaces"), obj.GetName(), errors.New("the object has been modified"))
+							}
+						}
+						return client.Update(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			r := makeTestReconciler(cl, sch)
+
+			var mu sync.Mutex
+			status, errs := r.reconcileApplicationSetSourceNamespaceResources(context.TODO(), a, "foo", []string{"foo"}, &mu)
+			assert.Equal(t, test.wantUpdates, updates)
+			assert.Equal(t, test.wantState, status.State)
+			assert.Equal(t, test.wantState == argoproj.ApplicationSetSourceNamespaceFailed, len(errs) > 0)
+
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "foo"}, ns))
+			_, found := ns.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]
+			assert.Equal(t, test.wantLabel, found)
+		})
+	}
+}
+
+func TestReconcileApplicationSet_RoleBindingRoleRefChanged(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	oldRole := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "old-role", Namespace: a.Namespace}}
+	renamedRole := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "new-role", Namespace: a.Namespace}}
+
+	var calls []string
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a, oldRole, renamedRole).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*rbacv1.RoleBinding); ok {
+					calls = append(calls, "create")
+				}
+				return client.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if rb, ok := obj.(*rbacv1.RoleBinding); ok {
+					existing := &rbacv1.RoleBinding{}
+					if err := client.Get(ctx, types.NamespacedName{Name: rb.Name, Namespace: rb.Namespace}, existing); err != nil {
+						return err
+					}
+					// the API server rejects any change of the RoleRef of a RoleBinding
+					if existing.RoleRef != rb.RoleRef {
+						return errors.New("cannot change roleRef")
+					}
+					calls = append(calls, "update")
+				}
+				return client.Update(ctx, obj, opts...)
+			},
+			Delete: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if _, ok := obj.(*rbacv1.RoleBinding); ok {
+					calls = append(calls, "delete")
+				}
+				return client.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa-name", Namespace: a.Namespace}}
+	assert.NoError(t, r.reconcileApplicationSetRoleBinding(context.TODO(), a, oldRole, sa))
+	assert.NoError(t, r.reconcileApplicationSetRoleBinding(context.TODO(), a, oldRole, sa))
+	assert.Equal(t, []string{"create", "update"}, calls)
+
+	calls = nil
+	assert.NoError(t, r.reconcileApplicationSetRoleBinding(context.TODO(), a, renamedRole, sa))
+	assert.Equal(t, []string{"delete", "create"}, calls)
+
+	roleBinding := &rbacv1.RoleBinding{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}, roleBinding))
+	assert.Equal(t, "new-role", roleBinding.RoleRef.Name)
+	assert.Equal(t, "sa-name", roleBinding.Subjects[0].Name)
+}
+
 func appsetAssertExpectedLabels(t *testing.T, meta *metav1.ObjectMeta) {
 	assert.Equal(t, meta.Labels["app.kubernetes.io/name"], "argocd-applicationset-controller")
 	assert.Equal(t, meta.Labels["app.kubernetes.io/part-of"], "argocd-applicationset")
@@ -884,10 +2222,100 @@ func TestReconcileApplicationSet_Service(t *testing.T) {
 
 	s := newServiceWithSuffix(common.ApplicationSetServiceNameSuffix, common.ApplicationSetServiceNameSuffix, a)
 
-	assert.NoError(t, r.reconcileApplicationSetService(a))
+	assert.NoError(t, r.reconcileApplicationSetService(context.TODO(), a))
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
 }
 
+func TestReconcileApplicationSet_ServiceDrift(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationSetService(context.TODO(), a))
+
+	key := types.NamespacedName{Namespace: a.Namespace, Name: "argocd-applicationset-controller"}
+	want := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, want))
+
+	// modify the ports and selector outside of the operator
+	s := want.DeepCopy()
+	s.Spec.Ports[0].Port = 9000
+	s.Spec.Ports = s.Spec.Ports[:1]
+	s.Spec.Selector = map[string]string{"foo": "bar"}
+	assert.NoError(t, r.Client.Update(context.TODO(), s))
+
+	assert.NoError(t, r.reconcileApplicationSetService(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, s))
+	assert.Equal(t, want.Spec.Ports, s.Spec.Ports)
+	assert.Equal(t, want.Spec.Selector, s.Spec.Selector)
+
+	// an unchanged service is not updated
+	resourceVersion := s.ResourceVersion
+	assert.NoError(t, r.reconcileApplicationSetService(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, s))
+	assert.Equal(t, resourceVersion, s.ResourceVersion)
+}
+
+func TestReconcileApplicationSet_LabelsAndAnnotations(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		Labels: map[string]string{
+			"example.com/team":        "platform",
+			common.ArgoCDKeyName:      "overridden",
+			common.ArgoCDKeyPartOf:    "overridden",
+			common.ArgoCDKeyManagedBy: "overridden",
+		},
+		Annotations: map[string]string{"example.com/owner": "platform-team"},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationSetService(context.TODO(), a))
+	sa, err := r.reconcileApplicationSetServiceAccount(context.TODO(), a)
+	assert.NoError(t, err)
+	role, err := r.reconcileApplicationSetRole(context.TODO(), a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileApplicationSetRoleBinding(context.TODO(), a, role, sa))
+
+	key := types.NamespacedName{Namespace: a.Namespace, Name: "argocd-applicationset-controller"}
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, svc))
+	gotRole := &rbacv1.Role{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, gotRole))
+	gotRoleBinding := &rbacv1.RoleBinding{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, gotRoleBinding))
+	gotSA := &corev1.ServiceAccount{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, gotSA))
+
+	for _, meta := range []metav1.ObjectMeta{svc.ObjectMeta, gotRole.ObjectMeta, gotRoleBinding.ObjectMeta, gotSA.ObjectMeta} {
+		assert.Equal(t, "platform", meta.Labels["example.com/team"], meta.Name)
+		assert.Equal(t, "platform-team", meta.Annotations["example.com/owner"], meta.Name)
+		// the labels set by the operator are not overwritten
+		assert.NotEqual(t, "overridden", meta.Labels[common.ArgoCDKeyName], meta.Name)
+		assert.NotEqual(t, "overridden", meta.Labels[common.ArgoCDKeyPartOf], meta.Name)
+		assert.NotEqual(t, "overridden", meta.Labels[common.ArgoCDKeyManagedBy], meta.Name)
+	}
+
+	// labels changed in the spec are applied to the existing service
+	a.Spec.ApplicationSet.Labels["example.com/team"] = "apps"
+	assert.NoError(t, r.reconcileApplicationSetService(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, svc))
+	assert.Equal(t, "apps", svc.Labels["example.com/team"])
+}
+
 func TestArgoCDApplicationSetCommand(t *testing.T) {
 	a := makeTestArgoCD()
 	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
@@ -915,7 +2343,7 @@ func TestArgoCDApplicationSetCommand(t *testing.T) {
 	}
 
 	deployment := &appsv1.Deployment{}
-	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
 
 	assert.NoError(t, r.Client.Get(
 		context.TODO(),
@@ -937,7 +2365,7 @@ func TestArgoCDApplicationSetCommand(t *testing.T) {
 		"test",
 	}
 
-	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
 	assert.NoError(t, r.Client.Get(
 		context.TODO(),
 		types.NamespacedName{
@@ -955,7 +2383,7 @@ func TestArgoCDApplicationSetCommand(t *testing.T) {
 		"foo.scv.cluster.local:6379",
 	}
 
-	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
 	assert.NoError(t, r.Client.Get(
 		context.TODO(),
 		types.NamespacedName{
@@ -969,77 +2397,406 @@ func TestArgoCDApplicationSetCommand(t *testing.T) {
 	// Remove all the command arguments that were added.
 	a.Spec.ApplicationSet.ExtraCommandArgs = []string{}
 
-	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	assert.Equal(t, baseCommand, deployment.Spec.Template.Spec.Containers[0].Command)
+
+	// When the command is overridden, the default entrypoint is replaced and the computed flags are kept
+	a.Spec.ApplicationSet.Command = []string{"dlv", "exec", "/usr/local/bin/argocd-applicationset-controller", "--"}
+	a.Spec.ApplicationSet.ExtraCommandArgs = []string{"--foo", "bar"}
+
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	cmd = append([]string{"dlv", "exec", "/usr/local/bin/argocd-applicationset-controller", "--"}, baseCommand[2:]...)
+	cmd = append(cmd, "--foo", "bar")
+	assert.Equal(t, cmd, deployment.Spec.Template.Spec.Containers[0].Command)
+
+	// Removing the override restores the default entrypoint
+	a.Spec.ApplicationSet.Command = nil
+	a.Spec.ApplicationSet.ExtraCommandArgs = nil
+
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	assert.Equal(t, baseCommand, deployment.Spec.Template.Spec.Containers[0].Command)
+}
+
+func TestArgoCDApplicationSetEnv(t *testing.T) {
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	defaultEnv := []corev1.EnvVar{
+		{
+			Name: "NAMESPACE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					APIVersion: "",
+					FieldPath:  "metadata.namespace",
+				},
+			},
+		},
+	}
+
+	// Pass an environment variable using Argo CD CR.
+	customEnv := []corev1.EnvVar{
+		{
+			Name:  "foo",
+			Value: "bar",
+		},
+	}
+	a.Spec.ApplicationSet.Env = customEnv
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	expectedEnv := append(defaultEnv, customEnv...)
+	assert.Equal(t, expectedEnv, deployment.Spec.Template.Spec.Containers[0].Env)
+
+	// Remove all the env vars that were added.
+	a.Spec.ApplicationSet.Env = []corev1.EnvVar{}
+
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
 	assert.NoError(t, r.Client.Get(
 		context.TODO(),
 		types.NamespacedName{
 			Name:      "argocd-applicationset-controller",
 			Namespace: a.Namespace,
 		},
-		deployment))
+		deployment))
+
+	assert.Equal(t, defaultEnv, deployment.Spec.Template.Spec.Containers[0].Env)
+}
+
+func TestArgoCDApplicationSetEnv_update(t *testing.T) {
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		Env: []corev1.EnvVar{
+			{Name: "ZED", Value: "1"},
+			{Name: "ALPHA", Value: "1"},
+		},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	envNames := func(env []corev1.EnvVar) []string {
+		names := []string{}
+		for _, e := range env {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	firstEnv := deployment.Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, []string{"ALPHA", "NAMESPACE", "ZED"}, envNames(firstEnv))
+
+	// the env order must be stable across reconciles with the same input
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+		assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+		assert.Equal(t, firstEnv, deployment.Spec.Template.Spec.Containers[0].Env)
+	}
+
+	// changing the value of an existing env var updates the deployment
+	a.Spec.ApplicationSet.Env[0].Value = "2"
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "ZED", Value: "2"})
+}
+
+func TestReconcileApplicationSet_Deployments_noUpdateWhenUnchanged(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		Env: []corev1.EnvVar{
+			{Name: "ZED", Value: "1"},
+			{Name: "ALPHA", Value: "1"},
+		},
+		SidecarContainers: []corev1.Container{{Name: "sidecar", Image: "sidecar:latest"}},
+	}
 
-	assert.Equal(t, baseCommand, deployment.Spec.Template.Spec.Containers[0].Command)
+	updates := 0
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*appsv1.Deployment); ok {
+					updates++
+				}
+				return client.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "argocd-applicationset-controller"}}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, 0, updates)
+
+	// a change is still applied
+	a.Spec.ApplicationSet.Env[0].Value = "2"
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, 1, updates)
 }
 
-func TestArgoCDApplicationSetEnv(t *testing.T) {
+func TestReconcileApplicationSet_Deployments_noUpdateWithDefaultReplicas(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
 	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
 
-	resObjs := []client.Object{a}
-	subresObjs := []client.Object{a}
-	runtimeObjs := []runtime.Object{}
+	// the API server stores 1 replica for a deployment without replicas
+	defaultReplicas := func(obj client.Object) {
+		if deployment, ok := obj.(*appsv1.Deployment); ok && deployment.Spec.Replicas == nil {
+			replicas := int32(1)
+			deployment.Spec.Replicas = &replicas
+		}
+	}
+
+	updates := 0
 	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
-	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				defaultReplicas(obj)
+				return client.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*appsv1.Deployment); ok {
+					updates++
+				}
+				defaultReplicas(obj)
+				return client.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
 	r := makeTestReconciler(cl, sch)
 
-	defaultEnv := []corev1.EnvVar{
-		{
-			Name: "NAMESPACE",
-			ValueFrom: &corev1.EnvVarSource{
-				FieldRef: &corev1.ObjectFieldSelector{
-					APIVersion: "",
-					FieldPath:  "metadata.namespace",
-				},
-			},
-		},
-	}
+	sa := corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "argocd-applicationset-controller"}}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
 
-	// Pass an environment variable using Argo CD CR.
-	customEnv := []corev1.EnvVar{
-		{
-			Name:  "foo",
-			Value: "bar",
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, 0, updates)
+
+	// changing the replicas is still applied
+	replicas := int32(2)
+	a.Spec.ApplicationSet.Replicas = &replicas
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, 1, updates)
+}
+
+func TestReconcileApplicationSet_Deployments_noUpdateWithServerDefaults(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		ServiceAccountToken: &argoproj.ArgoCDServiceAccountTokenSpec{
+			Enabled: true,
 		},
 	}
-	a.Spec.ApplicationSet.Env = customEnv
 
+	updates := 0
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*appsv1.Deployment); ok {
+					updates++
+				}
+				return client.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "argocd-applicationset-controller"}}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+
+	// seed the stored deployment with the values the API server defaults
 	deployment := &appsv1.Deployment{}
-	assert.NoError(t, r.reconcileApplicationSetController(a))
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	defaultMode := int32(420)
+	for i, volume := range deployment.Spec.Template.Spec.Volumes {
+		if volume.ConfigMap != nil {
+			deployment.Spec.Template.Spec.Volumes[i].ConfigMap.DefaultMode = &defaultMode
+		}
+		if volume.Projected != nil {
+			deployment.Spec.Template.Spec.Volumes[i].Projected.DefaultMode = &defaultMode
+			for _, source := range volume.Projected.Sources {
+				if source.DownwardAPI != nil {
+					for j := range source.DownwardAPI.Items {
+						source.DownwardAPI.Items[j].FieldRef.APIVersion = "v1"
+					}
+				}
+			}
+		}
+	}
+	for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		if env.ValueFrom != nil && env.ValueFrom.FieldRef != nil {
+			env.ValueFrom.FieldRef.APIVersion = "v1"
+		}
+	}
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	updates = 0
+
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, 0, updates)
+
+	// a volume changed out of band is still restored
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	otherMode := int32(256)
+	for i, volume := range deployment.Spec.Template.Spec.Volumes {
+		if volume.ConfigMap != nil {
+			deployment.Spec.Template.Spec.Volumes[i].ConfigMap.DefaultMode = &otherMode
+		}
+	}
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	updates = 0
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, 1, updates)
+}
 
-	assert.NoError(t, r.Client.Get(
-		context.TODO(),
-		types.NamespacedName{
-			Name:      "argocd-applicationset-controller",
-			Namespace: a.Namespace,
+func TestReconcileApplicationSet_Deployments_noUpdateWithSidecarServerDefaults(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SidecarContainers: []corev1.Container{
+			{
+				Name:  "sidecar",
+				Image: "sidecar:v1",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)},
+					},
+				},
+			},
+			{Name: "latest", Image: "latest"},
 		},
-		deployment))
+	}
 
-	expectedEnv := append(defaultEnv, customEnv...)
-	assert.Equal(t, expectedEnv, deployment.Spec.Template.Spec.Containers[0].Env)
+	updates := 0
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*appsv1.Deployment); ok {
+					updates++
+				}
+				return client.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
 
-	// Remove all the env vars that were added.
-	a.Spec.ApplicationSet.Env = []corev1.EnvVar{}
+	sa := corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "argocd-applicationset-controller"}}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
 
-	assert.NoError(t, r.reconcileApplicationSetController(a))
-	assert.NoError(t, r.Client.Get(
-		context.TODO(),
-		types.NamespacedName{
-			Name:      "argocd-applicationset-controller",
-			Namespace: a.Namespace,
-		},
-		deployment))
+	// seed the stored sidecars with the values the API server defaults
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	sidecar := &deployment.Spec.Template.Spec.Containers[1]
+	sidecar.ImagePullPolicy = corev1.PullIfNotPresent
+	sidecar.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	sidecar.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	sidecar.Ports[0].Protocol = corev1.ProtocolTCP
+	sidecar.ReadinessProbe.HTTPGet.Scheme = corev1.URISchemeHTTP
+	sidecar.ReadinessProbe.TimeoutSeconds = 1
+	sidecar.ReadinessProbe.PeriodSeconds = 10
+	sidecar.ReadinessProbe.SuccessThreshold = 1
+	sidecar.ReadinessProbe.FailureThreshold = 3
+	latest := &deployment.Spec.Template.Spec.Containers[2]
+	latest.ImagePullPolicy = corev1.PullAlways
+	latest.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	latest.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	updates = 0
+
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, 0, updates)
+
+	// a change of a sidecar field that isn't compared for the controller container is applied
+	a.Spec.ApplicationSet.SidecarContainers[0].Ports[0].ContainerPort = 9090
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, 1, updates)
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, int32(9090), deployment.Spec.Template.Spec.Containers[1].Ports[0].ContainerPort)
+}
 
-	assert.Equal(t, defaultEnv, deployment.Spec.Template.Spec.Containers[0].Env)
+func TestDefaultImagePullPolicy(t *testing.T) {
+	tests := []struct {
+		image string
+		want  corev1.PullPolicy
+	}{
+		{image: "sidecar", want: corev1.PullAlways},
+		{image: "sidecar:latest", want: corev1.PullAlways},
+		{image: "localhost:5000/sidecar", want: corev1.PullAlways},
+		{image: "sidecar:v1", want: corev1.PullIfNotPresent},
+		{image: "localhost:5000/sidecar:v1", want: corev1.PullIfNotPresent},
+		{image: "sidecar@sha256:6b8f1a1d4e1d", want: corev1.PullIfNotPresent},
+	}
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			assert.Equal(t, test.want, defaultImagePullPolicy(test.image))
+		})
+	}
 }
 
 func TestArgoCDApplicationSet_getApplicationSetSourceNamespaces(t *testing.T) {
@@ -1092,6 +2849,23 @@ func TestArgoCDApplicationSet_getApplicationSetSourceNamespaces(t *testing.T) {
 	}
 }
 
+func TestGetResourceNameForApplicationSetSourceNamespaces(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.Equal(t, "argocd-argocd-applicationset", getResourceNameForApplicationSetSourceNamespaces(a))
+
+	// long names are truncated deterministically with a hash suffix
+	a.Name = strings.Repeat("a", 40)
+	a.Namespace = strings.Repeat("b", 40)
+	name := getResourceNameForApplicationSetSourceNamespaces(a)
+	assert.LessOrEqual(t, len(name), 63)
+	assert.Equal(t, name, getResourceNameForApplicationSetSourceNamespaces(a))
+
+	b := makeTestArgoCD()
+	b.Name = strings.Repeat("a", 40)
+	b.Namespace = strings.Repeat("b", 39) + "c"
+	assert.NotEqual(t, name, getResourceNameForApplicationSetSourceNamespaces(b))
+}
+
 func TestArgoCDApplicationSet_setManagedApplicationSetSourceNamespaces(t *testing.T) {
 	a := makeTestArgoCD()
 	ns1 := v1.Namespace{
@@ -1115,7 +2889,7 @@ func TestArgoCDApplicationSet_setManagedApplicationSetSourceNamespaces(t *testin
 	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
 	r := makeTestReconciler(cl, sch)
 
-	err := r.setManagedApplicationSetSourceNamespaces(a)
+	err := r.setManagedApplicationSetSourceNamespaces(context.TODO(), a)
 	assert.NoError(t, err)
 
 	assert.Equal(t, 1, len(r.ManagedApplicationSetSourceNamespaces))
@@ -1144,7 +2918,7 @@ func TestArgoCDApplicationSet_removeUnmanagedApplicationSetSourceNamespaceResour
 	createNamespace(r, ns2, "")
 
 	// create resources
-	err := r.reconcileApplicationSetSourceNamespacesResources(a)
+	err := r.reconcileApplicationSetSourceNamespacesResources(context.TODO(), a)
 	assert.NoError(t, err)
 
 	// remove appset ns
@@ -1156,7 +2930,7 @@ func TestArgoCDApplicationSet_removeUnmanagedApplicationSetSourceNamespaceResour
 	}
 
 	// clean up unmanaged namespaces resources
-	err = r.removeUnmanagedApplicationSetSourceNamespaceResources(a)
+	err = r.removeUnmanagedApplicationSetSourceNamespaceResources(context.TODO(), a)
 	assert.NoError(t, err)
 
 	// resources shouldn't exist in ns1
@@ -1196,3 +2970,435 @@ func TestArgoCDApplicationSet_removeUnmanagedApplicationSetSourceNamespaceResour
 	assert.True(t, found)
 	assert.Equal(t, a.Namespace, val)
 }
+
+func TestReconcileApplicationSet_APIMissing(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	applicationSetAPIFound = false
+	defer func() {
+		applicationSetAPIFound = true
+	}()
+
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+
+	deployment := &appsv1.Deployment{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}, deployment)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "ApplicationSetAPIMissing", events.Items[0].Reason)
+
+	assert.NoError(t, r.reconcileStatusApplicationSetController(a))
+	assert.Equal(t, "Failed", a.Status.ApplicationSetController)
+
+	// the controller is deployed once the CRDs are installed
+	applicationSetAPIFound = true
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}, deployment))
+	assert.Empty(t, r.warningEvents[types.NamespacedName{Name: a.Name, Namespace: a.Namespace}])
+}
+
+func TestReconcileApplicationSet_Deployments_ServiceAccountToken(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Nil(t, deployment.Spec.Template.Spec.AutomountServiceAccountToken)
+
+	expiration := int64(1800)
+	a.Spec.ApplicationSet.ServiceAccountToken = &argoproj.ArgoCDServiceAccountTokenSpec{
+		Enabled:           true,
+		Audience:          "argocd",
+		ExpirationSeconds: &expiration,
+	}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, boolPtr(false), podSpec.AutomountServiceAccountToken)
+
+	var volume *corev1.Volume
+	for i := range podSpec.Volumes {
+		if podSpec.Volumes[i].Name == "serviceaccount-token" {
+			volume = &podSpec.Volumes[i]
+		}
+	}
+	if assert.NotNil(t, volume) && assert.NotNil(t, volume.Projected) {
+		token := volume.Projected.Sources[0].ServiceAccountToken
+		assert.Equal(t, "argocd", token.Audience)
+		assert.Equal(t, &expiration, token.ExpirationSeconds)
+	}
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "serviceaccount-token",
+		MountPath: "/var/run/secrets/kubernetes.io/serviceaccount",
+		ReadOnly:  true,
+	})
+
+	// disabling the projected token restores the automounted one
+	a.Spec.ApplicationSet.ServiceAccountToken.Enabled = false
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Nil(t, deployment.Spec.Template.Spec.AutomountServiceAccountToken)
+	for _, v := range deployment.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "serviceaccount-token", v.Name)
+	}
+}
+
+func TestReconcileApplicationSet_Relabel(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	// resources created by a previous version of the operator, with the old labels
+	oldLabels := func() map[string]string {
+		return map[string]string{
+			"app.kubernetes.io/name":      "argocd-applicationset",
+			"app.kubernetes.io/component": "applicationset-controller",
+			"user-label":                  "kept",
+		}
+	}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+		Name: "argocd-applicationset-controller", Namespace: a.Namespace, Labels: oldLabels()}}
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{
+		Name: "argocd-applicationset-controller", Namespace: a.Namespace, Labels: oldLabels()}}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name: "argocd-applicationset-controller", Namespace: a.Namespace, Labels: oldLabels()}}
+
+	resObjs := []client.Object{a, sa, role, svc}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
+
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	for _, obj := range []client.Object{&corev1.ServiceAccount{}, &rbacv1.Role{}} {
+		assert.NoError(t, r.Client.Get(context.TODO(), key, obj))
+		labels := obj.GetLabels()
+		assert.Equal(t, "argocd-applicationset-controller", labels["app.kubernetes.io/name"])
+		assert.Equal(t, "argocd-applicationset", labels["app.kubernetes.io/part-of"])
+		assert.Equal(t, "controller", labels["app.kubernetes.io/component"])
+		assert.Equal(t, "kept", labels["user-label"])
+	}
+
+	updated := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, updated))
+	assert.Equal(t, "argocd-applicationset-controller", updated.Labels[common.ArgoCDKeyName])
+	assert.Equal(t, "applicationset-controller", updated.Labels[common.ArgoCDKeyComponent])
+	assert.Equal(t, "kept", updated.Labels["user-label"])
+}
+
+func TestReconcileApplicationSet_Deployments_SSHKnownHostsConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SSHKnownHostsConfigMap: "custom-known-hosts",
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	knownHostsConfigMap := func() string {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		}, deployment))
+		for _, v := range deployment.Spec.Template.Spec.Volumes {
+			if v.Name == "ssh-known-hosts" {
+				return v.ConfigMap.Name
+			}
+		}
+		return ""
+	}
+
+	// the default config map is used while the custom one doesn't exist
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, "argocd-ssh-known-hosts-cm", knownHostsConfigMap())
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "ApplicationSetKnownHostsConfigMapMissing", events.Items[0].Reason)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom-known-hosts", Namespace: a.Namespace},
+		Data:       map[string]string{"ssh_known_hosts": "git.example.com ssh-ed25519 AAAA"},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), cm))
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, "custom-known-hosts", knownHostsConfigMap())
+	assert.Empty(t, r.warningEvents[types.NamespacedName{Name: a.Name, Namespace: a.Namespace}])
+
+	a.Spec.ApplicationSet.SSHKnownHostsConfigMap = ""
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, "argocd-ssh-known-hosts-cm", knownHostsConfigMap())
+}
+
+func TestReconcileApplicationSet_GetErrorsPropagated(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	forbidden := apierrors.NewForbidden(appsv1.Resource("deployments"), "argocd-applicationset-controller", errors.New("forbidden"))
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithStatusSubresource(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				switch obj.(type) {
+				case *appsv1.Deployment, *corev1.Service:
+					return forbidden
+				}
+				return client.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	// the errors are returned rather than the resources being treated as existing
+	sa := corev1.ServiceAccount{}
+	assert.Equal(t, forbidden, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	assert.Equal(t, forbidden, r.reconcileApplicationSetService(context.TODO(), a))
+}
+
+func TestReconcileApplicationSet_Deployments_TrustedCAConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		TrustedCAConfigMap: "enterprise-ca",
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	getDeployment := func() *appsv1.Deployment {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+			Name:      "argocd-applicationset-controller",
+			Namespace: a.Namespace,
+		}, deployment))
+		return deployment
+	}
+	hasVolume := func(deployment *appsv1.Deployment) bool {
+		for _, v := range deployment.Spec.Template.Spec.Volumes {
+			if v.Name == "trusted-ca" {
+				assert.Equal(t, "enterprise-ca", v.ConfigMap.Name)
+				return true
+			}
+		}
+		return false
+	}
+
+	// nothing is mounted while the config map doesn't exist
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	deployment := getDeployment()
+	assert.False(t, hasVolume(deployment))
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "enterprise-ca", Namespace: a.Namespace},
+		Data:       map[string]string{"ca.crt": "-----BEGIN CERTIFICATE-----"},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), cm))
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	deployment = getDeployment()
+	assert.True(t, hasVolume(deployment))
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "trusted-ca",
+		MountPath: "/app/config/trusted-ca",
+		ReadOnly:  true,
+	})
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name:  "SSL_CERT_DIR",
+		Value: "/etc/ssl/certs:/app/config/trusted-ca",
+	})
+
+	a.Spec.ApplicationSet.TrustedCAConfigMap = ""
+	assert.NoError(t, r.reconcileApplicationSetDeployment(context.TODO(), a, &sa))
+	deployment = getDeployment()
+	assert.False(t, hasVolume(deployment))
+	for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		assert.NotEqual(t, "SSL_CERT_DIR", env.Name)
+	}
+}
+
+func TestReconcileApplicationSet_SourceNamespaceOwnership(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	first := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Namespace = "argocd-first"
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{SourceNamespaces: []string{"foo"}}
+	})
+	second := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Namespace = "argocd-second"
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{SourceNamespaces: []string{"foo"}}
+	})
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+
+	resObjs := []client.Object{first, second, ns}
+	subresObjs := []client.Object{first, second}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	owner := func() string {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "foo"}, ns))
+		return ns.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]
+	}
+	roleExists := func(cr *argoproj.ArgoCD) bool {
+		err := r.Client.Get(context.TODO(), types.NamespacedName{
+			Name:      getResourceNameForApplicationSetSourceNamespaces(cr),
+			Namespace: "foo",
+		}, &rbacv1.Role{})
+		return err == nil
+	}
+
+	var mu sync.Mutex
+	status, errs := r.reconcileApplicationSetSourceNamespaceResources(context.TODO(), first, "foo", []string{"foo"}, &mu)
+	assert.Empty(t, errs)
+	assert.Equal(t, argoproj.ApplicationSetSourceNamespaceReconciled, status.State)
+	assert.Equal(t, "argocd-first", owner())
+	assert.True(t, roleExists(first))
+
+	// the namespace stays owned by the first instance, whichever order the instances are reconciled in
+	for i := 0; i < 2; i++ {
+		status, errs = r.reconcileApplicationSetSourceNamespaceResources(context.TODO(), second, "foo", []string{"foo"}, &mu)
+		assert.Empty(t, errs)
+		assert.Equal(t, argoproj.ApplicationSetSourceNamespaceSkipped, status.State)
+		assert.Contains(t, status.Message, "argocd-first")
+		assert.Equal(t, "argocd-first", owner())
+		assert.False(t, roleExists(second))
+
+		status, _ = r.reconcileApplicationSetSourceNamespaceResources(context.TODO(), first, "foo", []string{"foo"}, &mu)
+		assert.Equal(t, argoproj.ApplicationSetSourceNamespaceReconciled, status.State)
+		assert.Equal(t, "argocd-first", owner())
+	}
+
+	// the cleanup of the second instance doesn't remove the label of the first one
+	assert.NoError(t, r.cleanupUnmanagedApplicationSetSourceNamespaceResources(context.TODO(), second, "foo"))
+	assert.Equal(t, "argocd-first", owner())
+	assert.True(t, roleExists(first))
+
+	// once released by the first instance, the namespace can be managed by the second one
+	assert.NoError(t, r.cleanupUnmanagedApplicationSetSourceNamespaceResources(context.TODO(), first, "foo"))
+	assert.Equal(t, "", owner())
+	assert.False(t, roleExists(first))
+
+	status, errs = r.reconcileApplicationSetSourceNamespaceResources(context.TODO(), second, "foo", []string{"foo"}, &mu)
+	assert.Empty(t, errs)
+	assert.Equal(t, argoproj.ApplicationSetSourceNamespaceReconciled, status.State)
+	assert.Equal(t, "argocd-second", owner())
+	assert.True(t, roleExists(second))
+}
+
+func TestReconcileApplicationSet_SourceNamespaceTransientGetError(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	tests := []struct {
+		name      string
+		err       error
+		failures  int
+		wantGets  int
+		wantState string
+	}{
+		{
+			name:      "transient errors are retried",
+			err:       apierrors.NewServiceUnavailable("etcd leader changed"),
+			failures:  2,
+			wantGets:  3,
+			wantState: argoproj.ApplicationSetSourceNamespaceReconciled,
+		},
+		{
+			name:      "persistent transient errors give up after a bounded number of retries",
+			err:       apierrors.NewTooManyRequests("slow down", 0),
+			failures:  100,
+			wantGets:  namespaceGetBackoff.Steps,
+			wantState: argoproj.ApplicationSetSourceNamespaceFailed,
+		},
+		{
+			name:      "other errors are not retried",
+			err:       apierrors.NewForbidden(corev1.Resource("namespaces"), "foo", errors.New("forbidden")),
+			failures:  100,
+			wantGets:  1,
+			wantState: argoproj.ApplicationSetSourceNamespaceFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD()
+			a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{SourceNamespaces: []string{"foo"}}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+
+			gets := 0
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := fake.NewClientBuilder().
+				WithScheme(sch).
+				WithObjects(a, ns).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							gets++
+							if gets <= test.failures {
+								return test.err
+							}
+						}
+						return client.Get(ctx, key, obj, opts...)
+					},
+				}).
+				Build()
+			r := makeTestReconciler(cl, sch)
+
+			var mu sync.Mutex
+			status, errs := r.reconcileApplicationSetSourceNamespaceResources(context.TODO(), a, "foo", []string{"foo"}, &mu)
+			assert.Equal(t, test.wantState, status.State)
+			assert.Equal(t, test.wantState == argoproj.ApplicationSetSourceNamespaceFailed, len(errs) > 0)
+			if test.wantState == argoproj.ApplicationSetSourceNamespaceFailed {
+				assert.Equal(t, test.wantGets, gets)
+			} else {
+				// the namespace is read again when labelling it
+				assert.GreaterOrEqual(t, gets, test.wantGets)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,6 +53,20 @@ type ReconcileArgoCD struct {
 	ManagedApplicationSetSourceNamespaces map[string]string
 	// Stores label selector used to reconcile a subset of ArgoCD
 	LabelSelector string
+	// Caches the namespaces matching spec.sourceNamespaces of each ArgoCD instance for the duration of a single reconcile
+	sourceNamespaces map[types.NamespacedName][]string
+	// Guards sourceNamespaces, as instances may be reconciled concurrently
+	sourceNamespacesMutex sync.Mutex
+	// Stores the ArgoCD instance referencing each config map set as .spec.applicationSet.scmRootCAConfigMap
+	scmRootCAConfigMaps map[types.NamespacedName]types.NamespacedName
+	// Guards scmRootCAConfigMaps, as instances may be reconciled concurrently
+	scmRootCAConfigMapsMutex sync.Mutex
+	// Stores the message of the last warning event emitted for each ArgoCD instance, keyed by event reason
+	warningEvents map[types.NamespacedName]map[string]string
+	// Stores the time of the last sweep of the cluster scoped resources left behind by deleted ArgoCD instances
+	lastOrphanedClusterResourcesSweep time.Time
+	// Guards lastOrphanedClusterResourcesSweep, as instances may be reconciled concurrently
+	orphanedClusterResourcesSweepMutex sync.Mutex
 }
 
 var log = logr.Log.WithName("controller_argocd")
@@ -70,7 +86,7 @@ var ActiveInstanceMap = make(map[string]string)
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=*
 //+kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=*
 //+kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
-//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=*
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=*
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;prometheusrules;servicemonitors,verbs=*
 //+kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=*
 //+kubebuilder:rbac:groups=argoproj.io,resources=applications;appprojects,verbs=*
@@ -95,15 +111,27 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		ReconcileTime.WithLabelValues(request.Namespace).Observe(time.Since(reconcileStartTS).Seconds())
 	}()
 
+	// bound the duration of the reconcile so that an unresponsive API server cannot block it indefinitely
+	ctx, cancel := context.WithTimeout(ctx, getReconcileTimeout())
+	defer cancel()
+
 	reqLogger := logr.FromContext(ctx, "namespace", request.Namespace, "name", request.Name)
 	reqLogger.Info("Reconciling ArgoCD")
 
+	// source namespaces are cached only for the duration of this reconcile
+	defer r.invalidateSourceNamespaces(request.NamespacedName)
+
 	argocd := &argoproj.ArgoCD{}
 	err := r.Client.Get(ctx, request.NamespacedName, argocd)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Owned objects are automatically garbage collected. Cluster scoped resources are deleted by the
+			// finalizer, unless the object was deleted without it. The sweep is throttled, as the garbage collection
+			// of the owned objects triggers many requests for the deleted object.
+			if err := r.sweepOrphanedClusterResources(ctx); err != nil {
+				reqLogger.Error(err, "failed to delete orphaned cluster resources")
+			}
 			// Return and don't requeue
 			return reconcile.Result{}, nil
 		}
@@ -158,6 +186,16 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		ReconcileTime.DeletePartialMatch(prometheus.Labels{"namespace": argocd.Namespace})
 
 		if argocd.IsDeletionFinalizerPresent() {
+			// the workloads, notably the application controller, must not lose their permissions while they are running
+			terminated, err := r.deleteWorkloads(argocd)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to delete workloads: %w", err)
+			}
+			if !terminated && time.Since(argocd.GetDeletionTimestamp().Time) < workloadTerminationTimeout {
+				reqLogger.Info("waiting for the workloads to terminate before deleting cluster resources")
+				return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+			}
+
 			if err := r.deleteClusterResources(argocd); err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to delete ClusterResources: %w", err)
 			}
@@ -168,11 +206,21 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 				}
 			}
 
+			// the managed source namespaces are only tracked in memory, rebuild them from the namespace labels in case
+			// the operator restarted since the instance was last reconciled
+			if err := r.setManagedSourceNamespaces(argocd); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to list sourceNamespaces, error: %w", err)
+			}
+
+			if err := r.setManagedApplicationSetSourceNamespaces(ctx, argocd); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to list applicationSetSourceNamespaces, error: %w", err)
+			}
+
 			if err := r.removeUnmanagedSourceNamespaceResources(argocd); err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to remove resources from sourceNamespaces, error: %w", err)
 			}
 
-			if err := r.removeUnmanagedApplicationSetSourceNamespaceResources(argocd); err != nil {
+			if err := r.removeUnmanagedApplicationSetSourceNamespaceResources(ctx, argocd); err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to remove resources from applicationSetSourceNamespaces, error: %w", err)
 			}
 
@@ -183,6 +231,7 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 			// remove namespace of deleted Argo CD instance from deprecationEventEmissionTracker (if exists) so that if another instance
 			// is created in the same namespace in the future, that instance is appropriately tracked
 			delete(DeprecationEventEmissionTracker, argocd.Namespace)
+			delete(r.warningEvents, request.NamespacedName)
 		}
 		return reconcile.Result{}, nil
 	}
@@ -198,6 +247,10 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
+	if err = r.cacheSourceNamespaces(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if err = r.setManagedNamespaces(argocd); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -206,17 +259,37 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
-	if err = r.setManagedApplicationSetSourceNamespaces(argocd); err != nil {
+	if err = r.setManagedApplicationSetSourceNamespaces(ctx, argocd); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if err := r.reconcileResources(argocd); err != nil {
+	if err = r.validateSourceNamespaces(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err = r.validateLogSettings(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err = r.validateRemoteRedis(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err = r.validateApplicationSet(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.reconcileResources(ctx, argocd); err != nil {
 		// Error reconciling ArgoCD sub-resources - requeue the request.
 		return reconcile.Result{}, err
 	}
 
-	// Return and don't requeue
-	return reconcile.Result{}, nil
+	if err := r.sweepOrphanedClusterResources(ctx); err != nil {
+		reqLogger.Error(err, "failed to delete orphaned cluster resources")
+	}
+
+	// Requeue to correct drift that is not caught by the watches, if a resync period is configured
+	return reconcile.Result{RequeueAfter: getResyncPeriod()}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -17,12 +17,17 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/rbac/v1"
@@ -114,6 +119,110 @@ func TestReconcileArgoCD_Reconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_Reconcile_resyncPeriod(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv(common.ArgoCDResyncPeriodEnvName, "15m")
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		},
+	}
+
+	res, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, res.RequeueAfter)
+}
+
+func TestReconcileArgoCD_Reconcile_componentReconcileTime(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	ComponentReconcileTime.Reset()
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		},
+	}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	for _, component := range []string{"sso", "secrets", "deployments", "statefulsets"} {
+		m := &dto.Metric{}
+		assert.NoError(t, ComponentReconcileTime.WithLabelValues(component).(prometheus.Histogram).Write(m))
+		assert.Equal(t, uint64(1), m.GetHistogram().GetSampleCount(), component)
+	}
+}
+
+func TestReconcileArgoCD_Reconcile_SourceNamespacesListedOnce(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SourceNamespaces = []string{"foo", "bar"}
+		cr.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+			SourceNamespaces: []string{"foo", "bar"},
+		}
+	})
+
+	// count the unfiltered namespace lists, which are issued by getSourceNamespaces
+	sourceNamespaceLists := 0
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithStatusSubresource(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listOpts := &client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				if _, ok := list.(*corev1.NamespaceList); ok && listOpts.LabelSelector == nil {
+					sourceNamespaceLists++
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, createNamespace(r, "foo", ""))
+	assert.NoError(t, createNamespace(r, "bar", ""))
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		},
+	}
+
+	for i := 1; i <= 2; i++ {
+		_, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, i, sourceNamespaceLists)
+		assert.Empty(t, r.sourceNamespaces)
+	}
+}
+
 func TestReconcileArgoCD_LabelSelector(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	//ctx := context.Background()
@@ -370,6 +479,294 @@ func TestReconcileArgoCD_CleanUp(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_deleteOrphanedClusterResources(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	// an instance that was deleted without its finalizer running
+	orphaned := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Name = "deleted"
+		cr.Namespace = "other"
+	})
+	legacy := newClusterRole("legacy", []v1.PolicyRule{}, orphaned)
+	legacy.Annotations = nil
+
+	resources := []client.Object{a, legacy}
+	resources = append(resources, clusterResources(a)...)
+	resources = append(resources, clusterResources(orphaned)...)
+
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resources, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the deletion of an instance triggers a reconcile request for it
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      orphaned.Name,
+			Namespace: orphaned.Namespace,
+		},
+	}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	for _, obj := range clusterResources(orphaned) {
+		assert.False(t, argoutil.IsObjectFound(r.Client, "", obj.GetName(), obj), "expected %s to be deleted", obj.GetName())
+	}
+	for _, obj := range clusterResources(a) {
+		assert.True(t, argoutil.IsObjectFound(r.Client, "", obj.GetName(), obj), "expected %s to be kept", obj.GetName())
+	}
+	// resources without owner annotations can't be attributed and are kept
+	assert.True(t, argoutil.IsObjectFound(r.Client, "", legacy.Name, legacy))
+}
+
+func TestReconcileArgoCD_sweepOrphanedClusterResources(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	orphaned := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Name = "deleted"
+		cr.Namespace = "other"
+	})
+
+	resources := clusterResources(orphaned)
+	subresObjs := []client.Object{}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resources, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the sweep is skipped until the interval has elapsed since the last one
+	r.lastOrphanedClusterResourcesSweep = time.Now()
+	assert.NoError(t, r.sweepOrphanedClusterResources(context.TODO()))
+	for _, obj := range clusterResources(orphaned) {
+		assert.True(t, argoutil.IsObjectFound(r.Client, "", obj.GetName(), obj))
+	}
+
+	r.lastOrphanedClusterResourcesSweep = time.Now().Add(-orphanedClusterResourcesSweepInterval)
+	assert.NoError(t, r.sweepOrphanedClusterResources(context.TODO()))
+	for _, obj := range clusterResources(orphaned) {
+		assert.False(t, argoutil.IsObjectFound(r.Client, "", obj.GetName(), obj))
+	}
+}
+
+func TestReconcileArgoCD_sweepOrphanedClusterResources_concurrent(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	var (
+		mu     sync.Mutex
+		sweeps int
+		fail   = true
+	)
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*v1.ClusterRoleList); ok {
+					mu.Lock()
+					defer mu.Unlock()
+					sweeps++
+					if fail {
+						return fmt.Errorf("list failed")
+					}
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	// a failed sweep is retried by the next reconcile
+	assert.Error(t, r.sweepOrphanedClusterResources(context.TODO()))
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+
+	// concurrent reconciles sweep only once
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, r.sweepOrphanedClusterResources(context.TODO()))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2, sweeps)
+}
+
+func TestReconcileArgoCD_deleteOrphanedClusterResources_throttled(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	sweeps := 0
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*v1.ClusterRoleList); ok {
+					sweeps++
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	// the garbage collection of the owned objects of a deleted instance triggers many requests for it
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "deleted",
+			Namespace: "other",
+		},
+	}
+	for i := 0; i < 5; i++ {
+		_, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, sweeps)
+}
+
+func TestReconcileArgoCD_CleanUp_applicationSetSourceNamespacesAfterRestart(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(deletedAt(time.Now()), addFinalizer(common.ArgoCDDeletionFinalizer), func(a *argoproj.ArgoCD) {
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+			SourceNamespaces: []string{"foo"},
+		}
+	})
+
+	managedNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "foo",
+		Labels: map[string]string{common.ArgoCDApplicationSetManagedByClusterArgoCDLabel: a.Namespace},
+	}}
+	otherNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "bar",
+		Labels: map[string]string{common.ArgoCDApplicationSetManagedByClusterArgoCDLabel: "other-argocd"},
+	}}
+	resName := getResourceNameForApplicationSetSourceNamespaces(a)
+	resources := []client.Object{a, managedNs, otherNs}
+	for _, ns := range []string{managedNs.Name, otherNs.Name} {
+		resources = append(resources,
+			&v1.Role{ObjectMeta: metav1.ObjectMeta{Name: resName, Namespace: ns}},
+			&v1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: resName, Namespace: ns}},
+		)
+	}
+
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resources, subresObjs, runtimeObjs)
+	// a freshly started operator has not tracked any source namespace yet
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(context.TODO(), types.NamespacedName{Name: resName, Namespace: managedNs.Name}, &v1.Role{})))
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(context.TODO(), types.NamespacedName{Name: resName, Namespace: managedNs.Name}, &v1.RoleBinding{})))
+	ns := &corev1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: managedNs.Name}, ns))
+	assert.NotContains(t, ns.Labels, common.ArgoCDApplicationSetManagedByClusterArgoCDLabel)
+
+	// namespaces of other instances are left alone
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: resName, Namespace: otherNs.Name}, &v1.Role{}))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: otherNs.Name}, ns))
+	assert.Equal(t, "other-argocd", ns.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel])
+}
+
+func TestReconcileArgoCD_CleanUp_workloadsBeforeClusterResources(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(deletedAt(time.Now()), addFinalizer(common.ArgoCDDeletionFinalizer))
+
+	resources := []client.Object{
+		a,
+		newDeploymentWithSuffix("server", "server", a),
+		newStatefulSetWithSuffix("application-controller", "application-controller", a),
+	}
+	resources = append(resources, clusterResources(a)...)
+
+	var deleted []string
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(resources...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				switch obj.(type) {
+				case *appsv1.Deployment, *appsv1.StatefulSet:
+					deleted = append(deleted, "workload")
+				case *v1.ClusterRole, *v1.ClusterRoleBinding:
+					deleted = append(deleted, "rbac")
+				}
+				return client.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"workload", "workload", "rbac", "rbac", "rbac", "rbac"}, deleted)
+}
+
+func TestReconcileArgoCD_CleanUp_waitsForWorkloads(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	tests := []struct {
+		name             string
+		deletedAt        time.Time
+		wantClusterRBAC  bool
+		wantRequeueLater bool
+	}{
+		{
+			name:             "workloads still terminating",
+			deletedAt:        time.Now(),
+			wantClusterRBAC:  true,
+			wantRequeueLater: true,
+		},
+		{
+			name:            "workloads terminating for too long",
+			deletedAt:       time.Now().Add(-workloadTerminationTimeout),
+			wantClusterRBAC: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(deletedAt(test.deletedAt), addFinalizer(common.ArgoCDDeletionFinalizer))
+			// the finalizer keeps the deployment around, as its pods would in a cluster
+			deployment := newDeploymentWithSuffix("server", "server", a)
+			deployment.Finalizers = []string{"example.com/pods"}
+
+			resources := []client.Object{a, deployment}
+			resources = append(resources, clusterResources(a)...)
+
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resources, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+			assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+			res, err := r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantRequeueLater, res.RequeueAfter > 0)
+
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, deployment))
+			assert.NotNil(t, deployment.DeletionTimestamp)
+
+			for _, obj := range clusterResources(a) {
+				assert.Equal(t, test.wantClusterRBAC, argoutil.IsObjectFound(r.Client, "", obj.GetName(), obj))
+			}
+		})
+	}
+}
+
 func addFinalizer(finalizer string) argoCDOpt {
 	return func(a *argoproj.ArgoCD) {
 		a.Finalizers = append(a.Finalizers, finalizer)
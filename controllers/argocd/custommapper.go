@@ -202,28 +202,39 @@ func (r *ReconcileArgoCD) clusterSecretResourceMapper(ctx context.Context, o cli
 }
 
 // applicationSetSCMTLSConfigMapMapper maps a watch event on a configmap with name "argocd-appset-gitlab-scm-tls-certs-cm",
-// back to the ArgoCD object that we want to reconcile.
+// or on the configmap referenced by .spec.applicationSet.scmRootCAConfigMap, back to the ArgoCD object that we want to reconcile.
+// Events of other configmaps are filtered out by name, without listing the ArgoCD instances.
 func (r *ReconcileArgoCD) applicationSetSCMTLSConfigMapMapper(ctx context.Context, o client.Object) []reconcile.Request {
 	var result = []reconcile.Request{}
 
-	if o.GetName() == common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName {
-		argocds := &argoproj.ArgoCDList{}
-		if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
-			return result
+	if o.GetName() != common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName {
+		r.scmRootCAConfigMapsMutex.Lock()
+		argocd, ok := r.scmRootCAConfigMaps[client.ObjectKey{Name: o.GetName(), Namespace: o.GetNamespace()}]
+		r.scmRootCAConfigMapsMutex.Unlock()
+		if ok {
+			result = []reconcile.Request{
+				{NamespacedName: argocd},
+			}
 		}
+		return result
+	}
 
-		if len(argocds.Items) != 1 {
-			return result
-		}
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
 
-		argocd := argocds.Items[0]
-		namespacedName := client.ObjectKey{
-			Name:      argocd.Name,
-			Namespace: argocd.Namespace,
-		}
-		result = []reconcile.Request{
-			{NamespacedName: namespacedName},
-		}
+	if len(argocds.Items) != 1 {
+		return result
+	}
+
+	argocd := argocds.Items[0]
+	namespacedName := client.ObjectKey{
+		Name:      argocd.Name,
+		Namespace: argocd.Namespace,
+	}
+	result = []reconcile.Request{
+		{NamespacedName: namespacedName},
 	}
 
 	return result
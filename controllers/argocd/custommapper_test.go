@@ -20,6 +20,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -105,6 +107,63 @@ func TestReconcileArgoCD_clusterRoleBindingMapper(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_applicationSetSCMTLSConfigMapMapper(t *testing.T) {
+	argocd := &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "argocd",
+			Namespace: "argocd-operator",
+		},
+		Spec: argoproj.ArgoCDSpec{
+			ApplicationSet: &argoproj.ArgoCDApplicationSet{
+				SCMRootCAConfigMap: "my-scm-ca",
+			},
+		},
+	}
+
+	lists := 0
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(argocd).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				lists++
+				return c.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, r.reconcileApplicationSetSCMRootCAConfigMap(context.TODO(), argocd))
+	lists = 0
+
+	want := []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      "argocd",
+				Namespace: "argocd-operator",
+			},
+		},
+	}
+
+	for _, name := range []string{common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName, "my-scm-ca"} {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argocd-operator"}}
+		assert.Equal(t, want, r.applicationSetSCMTLSConfigMapMapper(context.TODO(), cm))
+	}
+
+	// only the well-known configmap requires listing the ArgoCD instances
+	assert.Equal(t, 1, lists)
+
+	unrelated := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "argocd-operator"}}
+	assert.Empty(t, r.applicationSetSCMTLSConfigMapMapper(context.TODO(), unrelated))
+	assert.Equal(t, 1, lists)
+
+	// the configmap is no longer mapped once the reference is cleared
+	argocd.Spec.ApplicationSet.SCMRootCAConfigMap = ""
+	assert.NoError(t, r.reconcileApplicationSetSCMRootCAConfigMap(context.TODO(), argocd))
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-scm-ca", Namespace: "argocd-operator"}}
+	assert.Empty(t, r.applicationSetSCMTLSConfigMapMapper(context.TODO(), cm))
+}
+
 func TestReconcileArgoCD_tlsSecretMapperRepoServer(t *testing.T) {
 	argocd := &argoproj.ArgoCD{
 		ObjectMeta: metav1.ObjectMeta{
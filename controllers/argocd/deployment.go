@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -207,11 +208,29 @@ func getArgoImportVolumes(cr *argoprojv1alpha1.ArgoCDExport) []corev1.Volume {
 	return volumes
 }
 
-func getArgoRedisArgs(useTLS bool) []string {
+// getArgoRedisArgs will return the args for the standalone Redis container. Persistence is disabled unless it is
+// configured in the spec.
+func getArgoRedisArgs(cr *argoproj.ArgoCD, useTLS bool) []string {
 	args := make([]string, 0)
 
-	args = append(args, "--save", "")
-	args = append(args, "--appendonly", "no")
+	save, appendOnly := "", "no"
+	if cr.Spec.Redis.Persistence != nil {
+		save = cr.Spec.Redis.Persistence.Save
+		if cr.Spec.Redis.Persistence.AppendOnly {
+			appendOnly = "yes"
+		}
+	}
+	args = append(args, "--save", save)
+	args = append(args, "--appendonly", appendOnly)
+
+	if maxMemory := getRedisMaxMemory(cr); maxMemory != "" {
+		policy := common.ArgoCDDefaultRedisMaxMemoryPolicy
+		if cr.Spec.Redis.MaxMemoryPolicy != "" {
+			policy = cr.Spec.Redis.MaxMemoryPolicy
+		}
+		args = append(args, "--maxmemory", maxMemory)
+		args = append(args, "--maxmemory-policy", policy)
+	}
 
 	if useTLS {
 		args = append(args, "--tls-port", "6379")
@@ -225,6 +244,20 @@ func getArgoRedisArgs(useTLS bool) []string {
 	return args
 }
 
+// getRedisMaxMemory will return the --maxmemory of the standalone Redis. When it isn't set in the spec, it's derived
+// from the memory limit of the Redis container, leaving headroom for the memory used by Redis itself. An empty value
+// means Redis runs without a memory bound.
+func getRedisMaxMemory(cr *argoproj.ArgoCD) string {
+	if cr.Spec.Redis.MaxMemory != "" {
+		return cr.Spec.Redis.MaxMemory
+	}
+	limit, ok := getRedisResources(cr).Limits[corev1.ResourceMemory]
+	if !ok || limit.IsZero() {
+		return ""
+	}
+	return strconv.FormatInt(limit.Value()*common.ArgoCDDefaultRedisMaxMemoryPercent/100, 10)
+}
+
 // getArgoRepoCommand will return the command for the ArgoCD Repo component.
 func getArgoRepoCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd := make([]string, 0)
@@ -405,6 +438,7 @@ func newDeploymentWithName(name string, component string, cr *argoproj.ArgoCD) *
 		deploy.Spec.Template.Spec.NodeSelector = argoutil.AppendStringMap(deploy.Spec.Template.Spec.NodeSelector, cr.Spec.NodePlacement.NodeSelector)
 		deploy.Spec.Template.Spec.Tolerations = cr.Spec.NodePlacement.Tolerations
 	}
+	deploy.Spec.Template.Spec.HostAliases = cr.Spec.HostAliases
 	return deploy
 }
 
@@ -460,11 +494,12 @@ func (r *ReconcileArgoCD) reconcileGrafanaDeployment(cr *argoproj.ArgoCD) error
 // reconcileRedisDeployment will ensure the Deployment resource is present for the ArgoCD Redis component.
 func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS bool) error {
 	deploy := newDeploymentWithSuffix("redis", "redis", cr)
+	applyDNSSettings(&deploy.Spec.Template.Spec, cr)
 
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
-		Args:            getArgoRedisArgs(useTLS),
+		Args:            getArgoRedisArgs(cr, useTLS),
 		Image:           getRedisContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "redis",
@@ -473,6 +508,16 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 				ContainerPort: common.ArgoCDDefaultRedisPort,
 			},
 		},
+		// the status of Redis, and so the phase of the ArgoCD, only becomes Running once Redis accepts connections
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt(common.ArgoCDDefaultRedisPort),
+				},
+			},
+			InitialDelaySeconds: int32(5),
+			PeriodSeconds:       int32(5),
+		},
 		Resources: getRedisResources(cr),
 		Env:       proxyEnvVars(),
 		SecurityContext: &corev1.SecurityContext{
@@ -493,6 +538,10 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 		},
 	}}
 
+	if isRedisMonitoringEnabled(cr) {
+		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, getRedisExporterContainer(cr, useTLS))
+	}
+
 	deploy.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", cr.Name, "argocd-redis")
 	deploy.Spec.Template.Spec.Volumes = []corev1.Volume{
 		{
@@ -512,8 +561,8 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 
 	existing := newDeploymentWithSuffix("redis", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !cr.Spec.Redis.IsEnabled() {
-			// Deployment exists but component enabled flag has been set to false, delete the Deployment
+		if !isLocalRedisEnabled(cr) {
+			// Deployment exists but component enabled flag has been set to false or a remote Redis is used, delete the Deployment
 			log.Info("Redis exists but should be disabled. Deleting existing redis.")
 			return r.Client.Delete(context.TODO(), deploy)
 		}
@@ -530,6 +579,8 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		updateHostAliases(existing, deploy, &changed)
+		updateDNSSettings(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
 
 		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Args, existing.Spec.Template.Spec.Containers[0].Args) {
 			existing.Spec.Template.Spec.Containers[0].Args = deploy.Spec.Template.Spec.Containers[0].Args
@@ -542,18 +593,25 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 			changed = true
 		}
 
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].ReadinessProbe, existing.Spec.Template.Spec.Containers[0].ReadinessProbe) {
+			existing.Spec.Template.Spec.Containers[0].ReadinessProbe = deploy.Spec.Template.Spec.Containers[0].ReadinessProbe
+			changed = true
+		}
+
 		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Resources, existing.Spec.Template.Spec.Containers[0].Resources) {
 			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
 			changed = true
 		}
 
+		updateRedisExporterContainer(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
 		return nil // Deployment found with nothing to do, move along...
 	}
 
-	if !cr.Spec.Redis.IsEnabled() {
+	if !isLocalRedisEnabled(cr) {
 		log.Info("Redis disabled. Skipping starting redis.")
 		return nil
 	}
@@ -570,6 +628,7 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 // reconcileRedisHAProxyDeployment will ensure the Deployment resource is present for the Redis HA Proxy component.
 func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) error {
 	deploy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
+	applyDNSSettings(&deploy.Spec.Template.Spec, cr)
 
 	deploy.Spec.Template.Spec.Affinity = &corev1.Affinity{
 		PodAntiAffinity: &corev1.PodAntiAffinity{
@@ -722,7 +781,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 
 	deploy.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", cr.Name, "argocd-redis-ha")
 
-	version, err := getClusterVersion(r.Client)
+	version, err := getClusterVersion(context.TODO(), r.Client)
 	if err != nil {
 		log.Error(err, "error getting cluster version")
 	}
@@ -732,8 +791,8 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 
 	existing := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !cr.Spec.HA.Enabled {
-			// Deployment exists but HA enabled flag has been set to false, delete the Deployment
+		if !(cr.Spec.HA.Enabled && isLocalRedisEnabled(cr)) {
+			// Deployment exists but either HA or component enabled flag has been set to false, or a remote Redis is used, delete the Deployment
 			return r.Client.Delete(context.TODO(), existing)
 		}
 		changed := false
@@ -746,6 +805,8 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		updateHostAliases(existing, deploy, &changed)
+		updateDNSSettings(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
 
 		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Resources, existing.Spec.Template.Spec.Containers[0].Resources) {
 			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
@@ -763,8 +824,8 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 		return nil // Deployment found, do nothing
 	}
 
-	if !cr.Spec.HA.Enabled {
-		return nil // HA not enabled, do nothing.
+	if !(cr.Spec.HA.Enabled && isLocalRedisEnabled(cr)) {
+		return nil // HA or local Redis not enabled, do nothing.
 	}
 
 	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
@@ -1022,6 +1083,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		updateHostAliases(existing, deploy, &changed)
 		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
 			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
 			changed = true
@@ -1217,6 +1279,7 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		updateHostAliases(existing, deploy, &changed)
 		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
 			deploy.Spec.Template.Spec.Containers[0].Env) {
 			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
@@ -1305,6 +1368,66 @@ func isRemoveManagedByLabelOnArgoCDDeletion() bool {
 	return false
 }
 
+// getReconcileTimeout returns the maximum duration of a single reconcile, configured through the
+// ARGOCD_RECONCILE_TIMEOUT environment variable as a Go duration string (e.g. "2m30s").
+func getReconcileTimeout() time.Duration {
+	if v := os.Getenv(common.ArgoCDReconcileTimeoutEnvName); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err == nil && timeout > 0 {
+			return timeout
+		}
+		log.Info(fmt.Sprintf("invalid value %q for %s, using the default of %s", v, common.ArgoCDReconcileTimeoutEnvName, common.ArgoCDDefaultReconcileTimeout))
+	}
+	return common.ArgoCDDefaultReconcileTimeout
+}
+
+// getResyncPeriod returns the interval after which a successfully reconciled ArgoCD is reconciled again, configured
+// through the ARGOCD_RESYNC_PERIOD environment variable as a Go duration string. Periodic resync is disabled when it
+// is unset or invalid.
+func getResyncPeriod() time.Duration {
+	if v := os.Getenv(common.ArgoCDResyncPeriodEnvName); v != "" {
+		period, err := time.ParseDuration(v)
+		if err == nil && period >= 0 {
+			return period
+		}
+		log.Info(fmt.Sprintf("invalid value %q for %s, periodic resync is disabled", v, common.ArgoCDResyncPeriodEnvName))
+	}
+	return 0
+}
+
+// applyDNSSettings sets the DNS policy and config of the given ArgoCD on the pod spec.
+func applyDNSSettings(podSpec *corev1.PodSpec, cr *argoproj.ArgoCD) {
+	podSpec.DNSPolicy = cr.Spec.DNSPolicy
+	podSpec.DNSConfig = cr.Spec.DNSConfig
+}
+
+// to update dnsPolicy and dnsConfig in reconciler. An unset DNS policy is defaulted to ClusterFirst by the API server,
+// so both are considered equal.
+func updateDNSSettings(existing *corev1.PodSpec, desired *corev1.PodSpec, changed *bool) {
+	dnsPolicyOrDefault := func(policy corev1.DNSPolicy) corev1.DNSPolicy {
+		if policy == "" {
+			return corev1.DNSClusterFirst
+		}
+		return policy
+	}
+	if dnsPolicyOrDefault(existing.DNSPolicy) != dnsPolicyOrDefault(desired.DNSPolicy) {
+		existing.DNSPolicy = desired.DNSPolicy
+		*changed = true
+	}
+	if !reflect.DeepEqual(existing.DNSConfig, desired.DNSConfig) {
+		existing.DNSConfig = desired.DNSConfig
+		*changed = true
+	}
+}
+
+// to update hostAliases in reconciler
+func updateHostAliases(existing *appsv1.Deployment, deploy *appsv1.Deployment, changed *bool) {
+	if !reflect.DeepEqual(existing.Spec.Template.Spec.HostAliases, deploy.Spec.Template.Spec.HostAliases) {
+		existing.Spec.Template.Spec.HostAliases = deploy.Spec.Template.Spec.HostAliases
+		*changed = true
+	}
+}
+
 // to update nodeSelector and tolerations in reconciler
 func updateNodePlacement(existing *appsv1.Deployment, deploy *appsv1.Deployment, changed *bool) {
 	if !reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, deploy.Spec.Template.Spec.NodeSelector) {
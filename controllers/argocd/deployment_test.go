@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -1454,6 +1455,8 @@ func TestReconcileArgoCD_reconcileRedisDeploymentWithoutTLS(t *testing.T) {
 		"--save",
 		"",
 		"--appendonly", "no",
+		"--maxmemory", "214748364",
+		"--maxmemory-policy", "allkeys-lru",
 	}
 
 	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
@@ -1465,6 +1468,81 @@ func TestReconcileArgoCD_reconcileRedisDeploymentWithoutTLS(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_reconcileRedisDeploymentWithPersistence(t *testing.T) {
+	cr := makeTestArgoCD()
+
+	resObjs := []client.Object{cr}
+	subresObjs := []client.Object{cr}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+
+	// enabling persistence updates the args of the existing deployment
+	cr.Spec.Redis.Persistence = &argoproj.ArgoCDRedisPersistenceSpec{
+		Save:       "900 1 300 10",
+		AppendOnly: true,
+	}
+	want := []string{
+		"--save", "900 1 300 10",
+		"--appendonly", "yes",
+		"--maxmemory", "214748364",
+		"--maxmemory-policy", "allkeys-lru",
+	}
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	d := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, d))
+	assert.Equal(t, want, d.Spec.Template.Spec.Containers[0].Args)
+}
+
+func TestGetArgoRedisArgs_maxMemory(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []argoCDOpt
+		want []string
+	}{
+		{
+			name: "derived from the default memory limit",
+			want: []string{"--maxmemory", "214748364", "--maxmemory-policy", "allkeys-lru"},
+		},
+		{
+			name: "derived from the memory limit",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.Redis.Resources = &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resourcev1.MustParse("1Gi")},
+				}
+			}},
+			want: []string{"--maxmemory", "858993459", "--maxmemory-policy", "allkeys-lru"},
+		},
+		{
+			name: "explicit maxmemory and policy",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.Redis.MaxMemory = "100mb"
+				a.Spec.Redis.MaxMemoryPolicy = "allkeys-lfu"
+			}},
+			want: []string{"--maxmemory", "100mb", "--maxmemory-policy", "allkeys-lfu"},
+		},
+		{
+			name: "no memory limit",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.Redis.Resources = &corev1.ResourceRequirements{}
+			}},
+			want: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCD(test.opts...)
+			want := append([]string{"--save", "", "--appendonly", "no"}, test.want...)
+			assert.Equal(t, want, getArgoRedisArgs(cr, false))
+		})
+	}
+}
+
 func TestReconcileArgoCD_reconcileRedisDeploymentWithTLS(t *testing.T) {
 	cr := makeTestArgoCD()
 
@@ -1478,6 +1556,8 @@ func TestReconcileArgoCD_reconcileRedisDeploymentWithTLS(t *testing.T) {
 	want := []string{
 		"--save", "",
 		"--appendonly", "no",
+		"--maxmemory", "214748364",
+		"--maxmemory-policy", "allkeys-lru",
 		"--tls-port", "6379",
 		"--port", "0",
 		"--tls-cert-file", "/app/config/redis/tls/tls.crt",
@@ -1514,6 +1594,98 @@ func TestReconcileArgoCD_reconcileRedisDeployment(t *testing.T) {
 	assert.Equal(t, int32(3), *d.Spec.Replicas)
 }
 
+func TestReconcileArgoCD_reconcileRedisDeployment_DNS(t *testing.T) {
+	cr := makeTestArgoCD()
+
+	resObjs := []client.Object{cr}
+	subresObjs := []client.Object{cr}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+
+	// the DNS config should be applied to the existing deployment
+	cr.Spec.DNSConfig = &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.53"}}
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+
+	d := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, d))
+	assert.Equal(t, cr.Spec.DNSConfig, d.Spec.Template.Spec.DNSConfig)
+}
+
+func TestReconcileArgoCD_reconcileRedis_switchToRemote(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	tests := []struct {
+		name      string
+		haEnabled bool
+		objects   []client.Object
+	}{
+		{
+			name: "standalone redis",
+			objects: []client.Object{
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "argocd-redis"}},
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "argocd-redis"}},
+			},
+		},
+		{
+			name:      "redis ha",
+			haEnabled: true,
+			objects: []client.Object{
+				&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "argocd-redis-ha-server"}},
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "argocd-redis-ha-haproxy"}},
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "argocd-redis-ha"}},
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "argocd-redis-ha-haproxy"}},
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "argocd-redis-ha-announce-0"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.HA.Enabled = test.haEnabled
+			})
+
+			resObjs := []client.Object{cr}
+			subresObjs := []client.Object{cr}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			reconcileRedis := func() {
+				assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+				assert.NoError(t, r.reconcileRedisHAProxyDeployment(cr))
+				assert.NoError(t, r.reconcileRedisStatefulSet(cr))
+				assert.NoError(t, r.reconcileRedisService(cr))
+				for i := 0; i < int(common.ArgoCDDefaultRedisHAReplicas); i++ {
+					// one announce service is deleted per reconcile
+					assert.NoError(t, r.reconcileRedisHAServices(cr))
+				}
+			}
+
+			// start with a local redis
+			reconcileRedis()
+			for _, obj := range test.objects {
+				assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: obj.GetName(), Namespace: cr.Namespace}, obj))
+			}
+
+			// switching to a remote redis removes the local redis resources
+			remote := "redis.example.com:6379"
+			cr.Spec.Redis.Remote = &remote
+			reconcileRedis()
+			for _, obj := range test.objects {
+				err := r.Client.Get(context.TODO(), types.NamespacedName{Name: obj.GetName(), Namespace: cr.Namespace}, obj)
+				assert.Truef(t, apierrors.IsNotFound(err), "%T %s should be deleted", obj, obj.GetName())
+			}
+			assert.Equal(t, "redis.example.com:6379", getRedisServerAddress(cr))
+		})
+	}
+}
+
 func TestReconcileArgoCD_reconcileRedisDeployment_testImageUpgrade(t *testing.T) {
 	// tests reconciler hook for redis deployment
 	cr := makeTestArgoCD()
@@ -2082,3 +2254,125 @@ func TestReconcileArgoCD_reconcileRepoDeployment_serviceAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestGetReconcileTimeout(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "unset", value: "", want: common.ArgoCDDefaultReconcileTimeout},
+		{name: "valid", value: "2m30s", want: 150 * time.Second},
+		{name: "negative", value: "-1m", want: common.ArgoCDDefaultReconcileTimeout},
+		{name: "invalid", value: "forever", want: common.ArgoCDDefaultReconcileTimeout},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(common.ArgoCDReconcileTimeoutEnvName, test.value)
+			assert.Equal(t, test.want, getReconcileTimeout())
+		})
+	}
+}
+
+func TestGetResyncPeriod(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "unset", value: "", want: 0},
+		{name: "valid", value: "10m", want: 10 * time.Minute},
+		{name: "negative", value: "-1m", want: 0},
+		{name: "invalid", value: "hourly", want: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(common.ArgoCDResyncPeriodEnvName, test.value)
+			assert.Equal(t, test.want, getResyncPeriod())
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcileRedis_metricsExporter(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	hasExporter := func(containers []corev1.Container) bool {
+		for _, container := range containers {
+			if container.Name == common.ArgoCDKeyMetrics {
+				assert.Equal(t, "oliver006/redis_exporter:v1.58.0", container.Image)
+				assert.Equal(t, int32(9121), container.Ports[0].ContainerPort)
+				return true
+			}
+		}
+		return false
+	}
+
+	tests := []struct {
+		name       string
+		haEnabled  bool
+		containers func(r *ReconcileArgoCD) []corev1.Container
+	}{
+		{
+			name: "standalone redis",
+			containers: func(r *ReconcileArgoCD) []corev1.Container {
+				deployment := &appsv1.Deployment{}
+				assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+				return deployment.Spec.Template.Spec.Containers
+			},
+		},
+		{
+			name:      "redis ha",
+			haEnabled: true,
+			containers: func(r *ReconcileArgoCD) []corev1.Container {
+				ss := &appsv1.StatefulSet{}
+				assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: testNamespace}, ss))
+				return ss.Spec.Template.Spec.Containers
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.HA.Enabled = test.haEnabled
+				a.Spec.Redis.Monitoring = &argoproj.ArgoCDRedisMonitoringSpec{Enabled: true}
+			})
+
+			resObjs := []client.Object{cr}
+			subresObjs := []client.Object{cr}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			reconcileRedis := func() {
+				assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+				assert.NoError(t, r.reconcileRedisStatefulSet(cr))
+				assert.NoError(t, r.reconcileRedisMetricsService(cr))
+			}
+
+			reconcileRedis()
+			containers := test.containers(r)
+			assert.True(t, hasExporter(containers))
+			assert.Equal(t, "redis", containers[0].Name)
+
+			svc := &corev1.Service{}
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-metrics", Namespace: testNamespace}, svc))
+			assert.Equal(t, int32(9121), svc.Spec.Ports[0].Port)
+
+			// reconciling again keeps a single exporter
+			reconcileRedis()
+			assert.Len(t, test.containers(r), len(containers))
+
+			cr.Spec.Redis.Monitoring.Enabled = false
+			reconcileRedis()
+			remaining := test.containers(r)
+			assert.False(t, hasExporter(remaining))
+			assert.Len(t, remaining, len(containers)-1)
+			assert.Equal(t, "redis", remaining[0].Name)
+
+			err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-metrics", Namespace: testNamespace}, svc)
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
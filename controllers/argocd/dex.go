@@ -46,12 +46,28 @@ func (r *ReconcileArgoCD) getDexOAuthClientSecret(cr *argoproj.ArgoCD) (*string,
 		return nil, err
 	}
 
-	// Find the token secret
+	// Find the token secret, dropping references to token secrets that no longer exist so that a new one is
+	// generated in their place.
 	var tokenSecret *corev1.ObjectReference
-	for _, saSecret := range sa.Secrets {
-		if strings.Contains(saSecret.Name, "token") {
+	saSecrets := []corev1.ObjectReference{}
+	staleRefs := false
+	for i := range sa.Secrets {
+		saSecret := sa.Secrets[i]
+		if tokenSecret == nil && strings.Contains(saSecret.Name, "token") {
+			if !argoutil.IsObjectFound(r.Client, cr.Namespace, saSecret.Name, &corev1.Secret{}) {
+				log.Info(fmt.Sprintf("dex service account token secret %s not found, a new one will be created", saSecret.Name))
+				staleRefs = true
+				continue
+			}
 			tokenSecret = &saSecret
-			break
+		}
+		saSecrets = append(saSecrets, saSecret)
+	}
+
+	if tokenSecret != nil && staleRefs {
+		sa.Secrets = saSecrets
+		if err := r.Client.Update(context.TODO(), sa); err != nil {
+			return nil, err
 		}
 	}
 
@@ -67,19 +83,19 @@ func (r *ReconcileArgoCD) getDexOAuthClientSecret(cr *argoproj.ArgoCD) (*string,
 			},
 			Type: corev1.SecretTypeServiceAccountToken,
 		}
-		err := r.Client.Create(context.TODO(), secret)
+		err := controllerutil.SetControllerReference(cr, secret, r.Scheme)
 		if err != nil {
-			return nil, e.New("unable to locate and create ServiceAccount token for OAuth client secret")
+			return nil, err
 		}
-		err = controllerutil.SetControllerReference(cr, secret, r.Scheme)
+		err = r.Client.Create(context.TODO(), secret)
 		if err != nil {
-			return nil, err
+			return nil, e.New("unable to locate and create ServiceAccount token for OAuth client secret")
 		}
 		tokenSecret = &corev1.ObjectReference{
 			Name:      secret.Name,
 			Namespace: cr.Namespace,
 		}
-		sa.Secrets = append(sa.Secrets, *tokenSecret)
+		sa.Secrets = append(saSecrets, *tokenSecret)
 		err = r.Client.Update(context.TODO(), sa)
 		if err != nil {
 			return nil, e.New("failed to add ServiceAccount token for OAuth client secret")
@@ -339,6 +355,7 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		updateHostAliases(existing, deploy, &changed)
 		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
 			deploy.Spec.Template.Spec.Containers[0].Env) {
 			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
@@ -378,6 +395,26 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 // reconcileDexService will ensure that the Service for Dex is present.
 func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("dex-server", "dex-server", cr)
+
+	ports := []corev1.ServicePort{
+		{
+			Name:       "http",
+			Port:       common.ArgoCDDefaultDexHTTPPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexHTTPPort),
+		}, {
+			Name:       "grpc",
+			Port:       common.ArgoCDDefaultDexGRPCPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexGRPCPort),
+		}, {
+			Name:       "metrics",
+			Port:       common.ArgoCDDefaultDexMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexMetricsPort),
+		},
+	}
+
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
 
 		// dex uninstallation requested
@@ -385,6 +422,12 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 			log.Info("deleting the existing Dex service because dex uninstallation has been requested")
 			return r.Client.Delete(context.TODO(), svc)
 		}
+
+		if !reflect.DeepEqual(svc.Spec.Ports, ports) {
+			// Services created by older versions of the operator do not expose the metrics port
+			svc.Spec.Ports = ports
+			return r.Client.Update(context.TODO(), svc)
+		}
 		return nil
 	}
 
@@ -396,20 +439,7 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("dex-server", cr),
 	}
-
-	svc.Spec.Ports = []corev1.ServicePort{
-		{
-			Name:       "http",
-			Port:       common.ArgoCDDefaultDexHTTPPort,
-			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexHTTPPort),
-		}, {
-			Name:       "grpc",
-			Port:       common.ArgoCDDefaultDexGRPCPort,
-			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexGRPCPort),
-		},
-	}
+	svc.Spec.Ports = ports
 
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
@@ -2,13 +2,11 @@ package argocd
 
 import (
 	"fmt"
-	"os"
 
 	corev1 "k8s.io/api/core/v1"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
-	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
 // getDexContainerImage will return the container image for the Dex server.
@@ -23,32 +21,13 @@ import (
 // 3. the default is configured in common.ArgoCDDefaultDexVersion and
 // common.ArgoCDDefaultDexImage.
 func getDexContainerImage(cr *argoproj.ArgoCD) string {
-	defaultImg, defaultTag := false, false
-
-	img := ""
-	tag := ""
-
-	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.Image != "" {
+	img, tag := "", ""
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil {
 		img = cr.Spec.SSO.Dex.Image
-	}
-
-	if img == "" {
-		img = common.ArgoCDDefaultDexImage
-		defaultImg = true
-	}
-
-	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.Version != "" {
 		tag = cr.Spec.SSO.Dex.Version
 	}
-
-	if tag == "" {
-		tag = common.ArgoCDDefaultDexVersion
-		defaultTag = true
-	}
-	if e := os.Getenv(common.ArgoCDDexImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
-	}
-	return argoutil.CombineImageTag(img, tag)
+	return resolveImage(img, tag, "", "",
+		common.ArgoCDDefaultDexImage, common.ArgoCDDefaultDexVersion, common.ArgoCDDexImageEnvName)
 }
 
 // getDexOAuthRedirectURI will return the OAuth redirect URI for the Dex server.
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -715,6 +716,60 @@ func TestReconcileArgoCD_reconcileDexService_removes_dex_when_disabled(t *testin
 	}
 }
 
+func TestReconcileArgoCD_reconcileDexService_ports(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileDexService(a))
+
+	service := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-dex-server", Namespace: a.Namespace}, service))
+
+	wantPorts := []corev1.ServicePort{
+		{
+			Name:       "http",
+			Port:       common.ArgoCDDefaultDexHTTPPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexHTTPPort),
+		}, {
+			Name:       "grpc",
+			Port:       common.ArgoCDDefaultDexGRPCPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexGRPCPort),
+		}, {
+			Name:       "metrics",
+			Port:       common.ArgoCDDefaultDexMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexMetricsPort),
+		},
+	}
+	assert.Equal(t, wantPorts, service.Spec.Ports)
+
+	// a service without the metrics port should be brought back in line
+	service.Spec.Ports = service.Spec.Ports[:2]
+	assert.NoError(t, r.Client.Update(context.TODO(), service))
+
+	assert.NoError(t, r.reconcileDexService(a))
+
+	service = &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-dex-server", Namespace: a.Namespace}, service))
+	assert.Equal(t, wantPorts, service.Spec.Ports)
+}
+
 // When Dex is enabled dex serviceaccount should be created, when disabled the Dex serviceaccount should be removed
 func TestReconcileArgoCD_reconcileDexServiceAccount_removes_dex_when_disabled(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
@@ -994,3 +1049,52 @@ func TestReconcileArgoCD_reconcileRoleBinding_dex_disabled(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_reconcileDexRoleAndRoleBinding(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	rules := policyRuleForDexServer()
+	assert.NoError(t, r.reconcileRoleBinding(common.ArgoCDDexServerComponent, rules, a))
+
+	role := &rbacv1.Role{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-dex-server", Namespace: a.Namespace}, role))
+	assert.Equal(t, rules, role.Rules)
+
+	roleBinding := &rbacv1.RoleBinding{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-dex-server", Namespace: a.Namespace}, roleBinding))
+	assert.Equal(t, []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      "argocd-argocd-dex-server",
+		Namespace: a.Namespace,
+	}}, roleBinding.Subjects)
+	wantRoleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     role.Name,
+	}
+	assert.Equal(t, wantRoleRef, roleBinding.RoleRef)
+
+	// a binding pointing at the wrong role is recreated
+	roleBinding.RoleRef.Name = "stale-role"
+	assert.NoError(t, r.Client.Update(context.TODO(), roleBinding))
+
+	assert.NoError(t, r.reconcileRoleBinding(common.ArgoCDDexServerComponent, rules, a))
+
+	roleBinding = &rbacv1.RoleBinding{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-dex-server", Namespace: a.Namespace}, roleBinding))
+	assert.Equal(t, wantRoleRef, roleBinding.RoleRef)
+}
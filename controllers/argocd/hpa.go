@@ -20,6 +20,7 @@ import (
 
 	autoscaling "k8s.io/api/autoscaling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -107,10 +108,78 @@ func (r *ReconcileArgoCD) reconcileServerHPA(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), defaultHPA)
 }
 
+// isApplicationSetAutoscaleEnabled returns true if the ApplicationSet controller is enabled and scaled by a
+// HorizontalPodAutoscaler.
+func isApplicationSetAutoscaleEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.IsEnabled() &&
+		cr.Spec.ApplicationSet.Autoscale != nil && cr.Spec.ApplicationSet.Autoscale.Enabled
+}
+
+// getApplicationSetHPASpec will return the HorizontalPodAutoscaler spec for the ApplicationSet controller.
+func getApplicationSetHPASpec(cr *argoproj.ArgoCD) autoscaling.HorizontalPodAutoscalerSpec {
+	autoscale := cr.Spec.ApplicationSet.Autoscale
+	spec := autoscaling.HorizontalPodAutoscalerSpec{
+		MaxReplicas:                    maxReplicas,
+		MinReplicas:                    &minReplicas,
+		TargetCPUUtilizationPercentage: &tcup,
+		ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       nameWithSuffix("applicationset-controller", cr),
+		},
+	}
+	if autoscale.MinReplicas != nil {
+		spec.MinReplicas = autoscale.MinReplicas
+	}
+	if autoscale.MaxReplicas > 0 {
+		spec.MaxReplicas = autoscale.MaxReplicas
+	}
+	if autoscale.TargetCPUUtilizationPercentage != nil {
+		spec.TargetCPUUtilizationPercentage = autoscale.TargetCPUUtilizationPercentage
+	}
+	return spec
+}
+
+// reconcileApplicationSetHPA will ensure that the HorizontalPodAutoscaler is present for the ApplicationSet controller
+// while its autoscaling is enabled, and reconcile any detected changes.
+func (r *ReconcileArgoCD) reconcileApplicationSetHPA(cr *argoproj.ArgoCD) error {
+	existingHPA := newHorizontalPodAutoscalerWithSuffix("applicationset-controller", cr)
+	found, err := argoutil.ObjectExists(r.Client, cr.Namespace, existingHPA.Name, existingHPA)
+	if err != nil {
+		return err
+	}
+	if found {
+		if !isApplicationSetAutoscaleEnabled(cr) {
+			return r.Client.Delete(context.TODO(), existingHPA) // ApplicationSet controller or its autoscaling disabled, delete it.
+		}
+
+		spec := getApplicationSetHPASpec(cr)
+		if !reflect.DeepEqual(existingHPA.Spec, spec) {
+			existingHPA.Spec = spec
+			return r.Client.Update(context.TODO(), existingHPA)
+		}
+		return nil // HorizontalPodAutoscaler found, no changes detected
+	}
+
+	if !isApplicationSetAutoscaleEnabled(cr) {
+		return nil // AutoScale not enabled, move along...
+	}
+
+	hpa := newHorizontalPodAutoscalerWithSuffix("applicationset-controller", cr)
+	hpa.Spec = getApplicationSetHPASpec(cr)
+	if err := controllerutil.SetControllerReference(cr, hpa, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), hpa)
+}
+
 // reconcileAutoscalers will ensure that all HorizontalPodAutoscalers are present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileAutoscalers(cr *argoproj.ArgoCD) error {
 	if err := r.reconcileServerHPA(cr); err != nil {
 		return err
 	}
+	if err := r.reconcileApplicationSetHPA(cr); err != nil {
+		return err
+	}
 	return nil
 }
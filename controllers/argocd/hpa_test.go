@@ -99,3 +99,58 @@ func TestReconcileHPA(t *testing.T) {
 	assert.True(t, errors.IsNotFound(err))
 
 }
+
+func TestReconcileApplicationSetHPA(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: testNamespace}
+	hpa := &autoscaling.HorizontalPodAutoscaler{}
+
+	// autoscaling not enabled, no HPA is created
+	assert.NoError(t, r.reconcileApplicationSetHPA(a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, hpa)))
+
+	a.Spec.ApplicationSet.Autoscale = &argoproj.ArgoCDApplicationSetAutoscaleSpec{Enabled: true}
+	assert.NoError(t, r.reconcileApplicationSetHPA(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, hpa))
+	assert.Equal(t, autoscaling.HorizontalPodAutoscalerSpec{
+		MaxReplicas:                    maxReplicas,
+		MinReplicas:                    &minReplicas,
+		TargetCPUUtilizationPercentage: &tcup,
+		ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "argocd-applicationset-controller",
+		},
+	}, hpa.Spec)
+
+	// the max replicas and target CPU are updated
+	a.Spec.ApplicationSet.Autoscale.MaxReplicas = max
+	a.Spec.ApplicationSet.Autoscale.TargetCPUUtilizationPercentage = &cpuUtil
+	assert.NoError(t, r.reconcileApplicationSetHPA(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, hpa))
+	assert.Equal(t, max, hpa.Spec.MaxReplicas)
+	assert.Equal(t, cpuUtil, *hpa.Spec.TargetCPUUtilizationPercentage)
+
+	// the HPA is deleted when the ApplicationSet controller is disabled
+	a.Spec.ApplicationSet.Enabled = boolPtr(false)
+	assert.NoError(t, r.reconcileApplicationSetHPA(a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, hpa)))
+
+	// and when autoscaling is disabled
+	a.Spec.ApplicationSet.Enabled = nil
+	assert.NoError(t, r.reconcileApplicationSetHPA(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, hpa))
+	a.Spec.ApplicationSet.Autoscale.Enabled = false
+	assert.NoError(t, r.reconcileApplicationSetHPA(a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, hpa)))
+}
@@ -36,8 +36,16 @@ var (
 		Help:    "Length of time per reconciliation per instance",
 		Buckets: []float64{0.05, 0.075, 0.1, 0.15, 0.2, 0.22, 0.24, 0.26, 0.28, 0.3, 0.32, 0.34, 0.37, 0.4, 0.42, 0.44, 0.48, 0.5, 0.55, 0.6, 0.75, 0.9, 1.00},
 	}, []string{"namespace"})
+
+	// ComponentReconcileTime is a prometheus metric which keeps track of the duration
+	// of the reconciliation of each component of an instance
+	ComponentReconcileTime = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "argocd_component_reconcile_seconds",
+		Help:    "Length of time per reconciliation of a component of an instance",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"component"})
 )
 
 func init() {
-	metrics.Registry.MustRegister(ActiveInstancesTotal, ActiveInstancesByPhase, ActiveInstanceReconciliationCount, ReconcileTime)
+	metrics.Registry.MustRegister(ActiveInstancesTotal, ActiveInstancesByPhase, ActiveInstanceReconciliationCount, ReconcileTime, ComponentReconcileTime)
 }
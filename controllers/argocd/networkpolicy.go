@@ -0,0 +1,126 @@
+// Copyright 2019 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// newNetworkPolicyWithSuffix returns a new NetworkPolicy instance for the given ArgoCD using the given suffix.
+func newNetworkPolicyWithSuffix(suffix string, component string, cr *argoproj.ArgoCD) *networkingv1.NetworkPolicy {
+	name := fmt.Sprintf("%s-%s", cr.Name, suffix)
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = name
+	lbls[common.ArgoCDKeyComponent] = component
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    lbls,
+		},
+	}
+}
+
+func isApplicationSetNetworkPolicyEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.IsEnabled() && cr.Spec.ApplicationSet.NetworkPolicy
+}
+
+// getApplicationSetNetworkPolicySpec returns the NetworkPolicy spec of the ApplicationSet controller pods. Webhook
+// events are sent by the SCM providers from outside the cluster, so the webhook port is open to any source, while
+// the metrics port is only open to Prometheus pods.
+func getApplicationSetNetworkPolicySpec(cr *argoproj.ArgoCD) networkingv1.NetworkPolicySpec {
+	tcp := corev1.ProtocolTCP
+	webhookPort := intstr.FromInt(7000)
+	metricsPort := intstr.FromInt(8080)
+
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				common.ArgoCDKeyName: nameWithSuffix("applicationset-controller", cr),
+			},
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &tcp, Port: &webhookPort},
+				},
+			},
+			{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &tcp, Port: &metricsPort},
+				},
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								common.ArgoCDKeyName: "prometheus",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// reconcileApplicationSetNetworkPolicy will ensure that the NetworkPolicy of the ApplicationSet controller is present
+// when enabled, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileApplicationSetNetworkPolicy(ctx context.Context, cr *argoproj.ArgoCD) error {
+	existing := newNetworkPolicyWithSuffix("applicationset-controller", "controller", cr)
+	found, err := argoutil.ObjectExists(r.Client, cr.Namespace, existing.Name, existing)
+	if err != nil {
+		return err
+	}
+	if found {
+		if !isApplicationSetNetworkPolicyEnabled(cr) {
+			log.Info(fmt.Sprintf("Deleting applicationset controller network policy %s as it is disabled", existing.Name))
+			return r.Client.Delete(ctx, existing)
+		}
+
+		// revert changes made to the policy outside of the operator
+		spec := getApplicationSetNetworkPolicySpec(cr)
+		if !equality.Semantic.DeepEqual(existing.Spec, spec) {
+			existing.Spec = spec
+			return r.Client.Update(ctx, existing)
+		}
+		return nil // NetworkPolicy found with nothing to do, move along...
+	}
+
+	if !isApplicationSetNetworkPolicyEnabled(cr) {
+		return nil
+	}
+
+	policy := newNetworkPolicyWithSuffix("applicationset-controller", "controller", cr)
+	policy.Spec = getApplicationSetNetworkPolicySpec(cr)
+	if err := controllerutil.SetControllerReference(cr, policy, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(ctx, policy)
+}
@@ -0,0 +1,75 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileApplicationSetNetworkPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	policy := &networkingv1.NetworkPolicy{}
+
+	// not created unless enabled
+	assert.NoError(t, r.reconcileApplicationSetNetworkPolicy(context.TODO(), a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, policy)))
+
+	a.Spec.ApplicationSet.NetworkPolicy = true
+	assert.NoError(t, r.reconcileApplicationSetNetworkPolicy(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, policy))
+
+	assert.Equal(t, map[string]string{"app.kubernetes.io/name": "argocd-applicationset-controller"}, policy.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, policy.Spec.PolicyTypes)
+	if assert.Len(t, policy.Spec.Ingress, 2) {
+		webhook := policy.Spec.Ingress[0]
+		assert.Equal(t, intstr.FromInt(7000), *webhook.Ports[0].Port)
+		assert.Empty(t, webhook.From)
+
+		metrics := policy.Spec.Ingress[1]
+		assert.Equal(t, intstr.FromInt(8080), *metrics.Ports[0].Port)
+		if assert.Len(t, metrics.From, 1) {
+			assert.Empty(t, metrics.From[0].NamespaceSelector.MatchLabels)
+			assert.Equal(t, map[string]string{"app.kubernetes.io/name": "prometheus"}, metrics.From[0].PodSelector.MatchLabels)
+		}
+	}
+
+	// changes made outside of the operator are reverted
+	policy.Spec.Ingress = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), policy))
+	assert.NoError(t, r.reconcileApplicationSetNetworkPolicy(context.TODO(), a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, policy))
+	assert.Len(t, policy.Spec.Ingress, 2)
+
+	// removed when disabled
+	a.Spec.ApplicationSet.NetworkPolicy = false
+	assert.NoError(t, r.reconcileApplicationSetNetworkPolicy(context.TODO(), a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, policy)))
+
+	// removed when the ApplicationSet controller is disabled
+	a.Spec.ApplicationSet.NetworkPolicy = true
+	assert.NoError(t, r.reconcileApplicationSetNetworkPolicy(context.TODO(), a))
+	a.Spec.ApplicationSet.Enabled = boolPtr(false)
+	assert.NoError(t, r.reconcileApplicationSetNetworkPolicy(context.TODO(), a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, policy)))
+}
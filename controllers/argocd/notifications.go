@@ -438,6 +438,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 
 	// deployment exists and should. Reconcile deployment if changed
 	updateNodePlacement(existingDeployment, desiredDeployment, &deploymentChanged)
+	updateHostAliases(existingDeployment, desiredDeployment, &deploymentChanged)
 
 	if existingDeployment.Spec.Template.Spec.Containers[0].Image != desiredDeployment.Spec.Template.Spec.Containers[0].Image {
 		existingDeployment.Spec.Template.Spec.Containers[0].Image = desiredDeployment.Spec.Template.Spec.Containers[0].Image
@@ -512,10 +513,18 @@ func (r *ReconcileArgoCD) reconcileNotificationsMetricsService(cr *argoproj.Argo
 
 	svc := newServiceWithSuffix(suffix, component, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if !cr.Spec.Notifications.Enabled {
+			log.Info(fmt.Sprintf("Deleting service %s as notifications is disabled", svc.Name))
+			return r.Client.Delete(context.TODO(), svc)
+		}
 		// Service found, do nothing
 		return nil
 	}
 
+	if !cr.Spec.Notifications.Enabled {
+		return nil
+	}
+
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix(component, cr),
 	}
@@ -541,7 +550,15 @@ func (r *ReconcileArgoCD) reconcileNotificationsServiceMonitor(cr *argoproj.Argo
 	name := fmt.Sprintf("%s-%s", cr.Name, "notifications-controller-metrics")
 	serviceMonitor := newServiceMonitorWithName(name, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, serviceMonitor.Name, serviceMonitor) {
-		// Service found, do nothing
+		if !cr.Spec.Notifications.Enabled {
+			log.Info(fmt.Sprintf("Deleting servicemonitor %s as notifications is disabled", serviceMonitor.Name))
+			return r.Client.Delete(context.TODO(), serviceMonitor)
+		}
+		// ServiceMonitor found, do nothing
+		return nil
+	}
+
+	if !cr.Spec.Notifications.Enabled {
 		return nil
 	}
 
@@ -559,6 +576,9 @@ func (r *ReconcileArgoCD) reconcileNotificationsServiceMonitor(cr *argoproj.Argo
 		},
 	}
 
+	if err := controllerutil.SetControllerReference(cr, serviceMonitor, r.Scheme); err != nil {
+		return err
+	}
 	return r.Client.Create(context.TODO(), serviceMonitor)
 }
 
@@ -615,6 +635,9 @@ func getNotificationsCommand(cr *argoproj.ArgoCD) []string {
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.Notifications.LogLevel))
 
+	cmd = append(cmd, "--logformat")
+	cmd = append(cmd, getLogFormat(cr.Spec.Notifications.LogFormat))
+
 	if cr.Spec.Repo.IsEnabled() {
 		cmd = append(cmd, "--argocd-repo-server", getRepoServerAddress(cr))
 	} else {
@@ -170,7 +170,7 @@ func TestReconcileNotifications_CreateDeployments(t *testing.T) {
 	assert.Equal(t, deployment.Spec.Template.Spec.ServiceAccountName, sa.ObjectMeta.Name)
 
 	want := []corev1.Container{{
-		Command:         []string{"argocd-notifications", "--loglevel", "info", "--argocd-repo-server", "argocd-repo-server.argocd.svc.cluster.local:8081"},
+		Command:         []string{"argocd-notifications", "--loglevel", "info", "--logformat", "text", "--argocd-repo-server", "argocd-repo-server.argocd.svc.cluster.local:8081"},
 		Image:           argoutil.CombineImageTag(common.ArgoCDDefaultArgoImage, common.ArgoCDDefaultArgoVersion),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "argocd-notifications-controller",
@@ -341,6 +341,42 @@ func TestReconcileNotifications_CreateServiceMonitor(t *testing.T) {
 		fmt.Sprintf("%s-%s", a.Name, "notifications-controller-metrics"))
 }
 
+func TestReconcileNotifications_DeleteMetricsServiceAndServiceMonitor(t *testing.T) {
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme, v1alpha1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	prometheusAPIFoundTemp := prometheusAPIFound
+	t.Cleanup(func() { prometheusAPIFound = prometheusAPIFoundTemp })
+	prometheusAPIFound = true
+
+	assert.NoError(t, r.reconcileNotificationsController(a))
+
+	name := types.NamespacedName{
+		Name:      fmt.Sprintf("%s-%s", a.Name, "notifications-controller-metrics"),
+		Namespace: a.Namespace,
+	}
+	assert.NoError(t, r.Client.Get(context.TODO(), name, &corev1.Service{}))
+	testServiceMonitor := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.Client.Get(context.TODO(), name, testServiceMonitor))
+	assert.Len(t, testServiceMonitor.OwnerReferences, 1)
+
+	// Notifications disabled, the metrics service and service monitor should be removed
+	a.Spec.Notifications.Enabled = false
+	assert.NoError(t, r.deleteNotificationsResources(a))
+
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), name, &corev1.Service{})))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), name, &monitoringv1.ServiceMonitor{})))
+}
+
 func TestReconcileNotifications_CreateSecret(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
@@ -470,6 +506,8 @@ func TestReconcileNotifications_testLogLevel(t *testing.T) {
 		"argocd-notifications",
 		"--loglevel",
 		"debug",
+		"--logformat",
+		"text",
 		"--argocd-repo-server",
 		"argocd-repo-server.argocd.svc.cluster.local:8081",
 	}
@@ -504,3 +542,57 @@ func TestReconcileNotifications_testLogLevel(t *testing.T) {
 		t.Fatalf("operator failed to override the manual changes to notification controller:\n%s", diff)
 	}
 }
+
+func TestReconcileNotifications_testLogFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		logLevel   string
+		logFormat  string
+		wantLevel  string
+		wantFormat string
+	}{
+		{"defaults", "", "", "info", "text"},
+		{"configured", "debug", "json", "debug", "json"},
+		{"invalid values fall back to defaults", "verbose", "yaml", "info", "text"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.Notifications.Enabled = true
+				a.Spec.Notifications.LogLevel = test.logLevel
+				a.Spec.Notifications.LogFormat = test.logFormat
+			})
+
+			resObjs := []client.Object{a}
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			sa := corev1.ServiceAccount{}
+			assert.NoError(t, r.reconcileNotificationsDeployment(a, &sa))
+
+			deployment := &appsv1.Deployment{}
+			assert.NoError(t, r.Client.Get(
+				context.TODO(),
+				types.NamespacedName{
+					Name:      a.Name + "-notifications-controller",
+					Namespace: a.Namespace,
+				},
+				deployment))
+
+			expectedCMD := []string{
+				"argocd-notifications",
+				"--loglevel",
+				test.wantLevel,
+				"--logformat",
+				test.wantFormat,
+				"--argocd-repo-server",
+				"argocd-repo-server.argocd.svc.cluster.local:8081",
+			}
+			assert.Equal(t, expectedCMD, deployment.Spec.Template.Spec.Containers[0].Command)
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package argocd
 
 import (
+	"context"
 	"fmt"
 
 	"golang.org/x/mod/semver"
 
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
 
 	v1 "k8s.io/api/rbac/v1"
@@ -365,7 +367,7 @@ func appendOpenShiftNonRootSCC(rules []v1.PolicyRule, client client.Client) []v1
 	if IsVersionAPIAvailable() {
 		// Starting with OpenShift 4.11, we need to use the resource name "nonroot-v2" instead of "nonroot"
 		resourceName := "nonroot"
-		version, err := getClusterVersion(client)
+		version, err := getClusterVersion(context.TODO(), client)
 		if err != nil {
 			log.Error(err, "couldn't get OpenShift version")
 		}
@@ -511,6 +513,24 @@ func policyRuleForApplicationSetController() []v1.PolicyRule {
 	}
 }
 
+// policyRuleForApplicationSetSourceNamespaces returns the rules of the ApplicationSet controller Role in
+// source namespaces. The token secrets referenced by SCM Provider and Pull Request generators are read
+// through the cached client, which needs get, list and watch, so the secrets rule is only left out when
+// these generators are disabled.
+func policyRuleForApplicationSetSourceNamespaces(cr *argoproj.ArgoCD) []v1.PolicyRule {
+	scmProvidersDisabled := cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.DisableSCMProviders != nil &&
+		*cr.Spec.ApplicationSet.DisableSCMProviders
+
+	var rules []v1.PolicyRule
+	for _, rule := range policyRuleForApplicationSetController() {
+		if scmProvidersDisabled && len(rule.Resources) == 1 && rule.Resources[0] == "secrets" {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
 func policyRuleForServerApplicationSetSourceNamespaces() []v1.PolicyRule {
 	return []v1.PolicyRule{
 		{
@@ -146,7 +146,7 @@ func (r *ReconcileArgoCD) reconcileMetricsServiceMonitor(cr *argoproj.ArgoCD) er
 	}
 	sm.Spec.Endpoints = []monitoringv1.Endpoint{
 		{
-			Port: common.ArgoCDKeyMetrics,
+			Port: getMetricsServicePortName(cr),
 		},
 	}
 
@@ -217,6 +217,38 @@ func (r *ReconcileArgoCD) reconcileRepoServerServiceMonitor(cr *argoproj.ArgoCD)
 	return r.Client.Create(context.TODO(), sm)
 }
 
+// reconcileRedisServiceMonitor will ensure that the ServiceMonitor is present for the Redis metrics Service.
+func (r *ReconcileArgoCD) reconcileRedisServiceMonitor(cr *argoproj.ArgoCD) error {
+	sm := newServiceMonitorWithSuffix("redis-metrics", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
+		if !isLocalRedisEnabled(cr) || !isRedisMonitoringEnabled(cr) {
+			// ServiceMonitor exists but the monitoring of Redis has been disabled, delete the ServiceMonitor
+			return r.Client.Delete(context.TODO(), sm)
+		}
+		return nil // ServiceMonitor found, do nothing
+	}
+
+	if !isLocalRedisEnabled(cr) || !isRedisMonitoringEnabled(cr) {
+		return nil // Redis monitoring not enabled, do nothing.
+	}
+
+	sm.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			common.ArgoCDKeyName: nameWithSuffix("redis-metrics", cr),
+		},
+	}
+	sm.Spec.Endpoints = []monitoringv1.Endpoint{
+		{
+			Port: common.ArgoCDKeyMetrics,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), sm)
+}
+
 // reconcileServerMetricsServiceMonitor will ensure that the ServiceMonitor is present for the ArgoCD Server metrics Service.
 func (r *ReconcileArgoCD) reconcileServerMetricsServiceMonitor(cr *argoproj.ArgoCD) error {
 	sm := newServiceMonitorWithSuffix("server-metrics", cr)
@@ -7,6 +7,7 @@ import (
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -208,3 +209,29 @@ func TestReconcileWorkloadStatusAlertRule(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileRedisServiceMonitor(t *testing.T) {
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Redis.Monitoring = &argoproj.ArgoCDRedisMonitoringSpec{Enabled: true}
+	})
+
+	resObjs := []client.Object{cr}
+	subresObjs := []client.Object{cr}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisServiceMonitor(cr))
+
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-metrics", Namespace: cr.Namespace}, sm))
+	assert.Equal(t, "argocd-redis-metrics", sm.Spec.Selector.MatchLabels["app.kubernetes.io/name"])
+	assert.Equal(t, "metrics", sm.Spec.Endpoints[0].Port)
+
+	cr.Spec.Redis.Monitoring.Enabled = false
+	assert.NoError(t, r.reconcileRedisServiceMonitor(cr))
+
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-metrics", Namespace: cr.Namespace}, sm)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -46,9 +47,24 @@ func generateResourceName(argoComponentName string, cr *argoproj.ArgoCD) string
 	return cr.Name + "-" + argoComponentName
 }
 
-// GenerateUniqueResourceName generates unique names for cluster scoped resources
+// GenerateUniqueResourceName generates unique names for cluster scoped resources. Names longer than 63 characters
+// are truncated with a hash suffix.
 func GenerateUniqueResourceName(argoComponentName string, cr *argoproj.ArgoCD) string {
-	return cr.Name + "-" + cr.Namespace + "-" + argoComponentName
+	return truncateWithHash(cr.Name+"-"+cr.Namespace+"-"+argoComponentName, validation.DNS1123LabelMaxLength)
+}
+
+// isClusterResourceOwnedBy returns true if the given cluster scoped resource was created for the given ArgoCD.
+// Names generated by GenerateUniqueResourceName can collide across instances (e.g. "a" in namespace "b-c" and
+// "a-b" in namespace "c"), so the owner annotations are compared instead. Resources without owner annotations
+// predate them and are considered owned.
+func isClusterResourceOwnedBy(obj metav1.Object, cr *argoproj.ArgoCD) bool {
+	annotations := obj.GetAnnotations()
+	name, hasName := annotations[common.AnnotationName]
+	namespace, hasNamespace := annotations[common.AnnotationNamespace]
+	if !hasName && !hasNamespace {
+		return true
+	}
+	return name == cr.Name && namespace == cr.Namespace
 }
 
 func newClusterRole(name string, rules []v1.PolicyRule, cr *argoproj.ArgoCD) *v1.ClusterRole {
@@ -196,7 +212,7 @@ func (r *ReconcileArgoCD) reconcileRoleForApplicationSourceNamespaces(name strin
 
 	for _, sourceNamespace := range sourceNamespaces {
 		namespace := &corev1.Namespace{}
-		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sourceNamespace}, namespace); err != nil {
+		if err := r.getNamespace(context.TODO(), sourceNamespace, namespace); err != nil {
 			return err
 		}
 		// do not reconcile roles for namespaces already containing managed-by label
@@ -324,3 +340,37 @@ func deleteClusterRoles(c client.Client, clusterRoleList *v1.ClusterRoleList) er
 	}
 	return nil
 }
+
+// ensureRole will create the given Role, or update the rules of the existing Role when they differ.
+func (r *ReconcileArgoCD) ensureRole(ctx context.Context, role v1.Role, cr *argoproj.ArgoCD) error {
+
+	if err := applyReconcilerHook(cr, role, ""); err != nil {
+		return err
+	}
+
+	existingRole := v1.Role{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, &existingRole)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to retrieve role %s in namespace %s: %w", role.Name, role.Namespace, err)
+		}
+
+		if err := r.Client.Create(ctx, &role); err != nil {
+			return fmt.Errorf("failed to create role %s in namespace %s: %w", role.Name, role.Namespace, err)
+		}
+
+		log.Info(fmt.Sprintf("role %s created successfully for Argo CD instance %s in namespace %s", role.Name, cr.Name, role.Namespace))
+		return nil
+	}
+
+	// if the Rules differ, update the Role, ignore if role is just created.
+	if !reflect.DeepEqual(existingRole.Rules, role.Rules) {
+		existingRole.Rules = role.Rules
+		if err := r.Client.Update(ctx, &existingRole); err != nil {
+			return fmt.Errorf("failed to update role %s in namespace %s: %w", role.Name, role.Namespace, err)
+		}
+		log.Info(fmt.Sprintf("role %s update successfully for Argo CD instance %s in namespace %s", role.Name, cr.Name, role.Namespace))
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -151,6 +152,19 @@ func TestReconcileArgoCD_reconcileClusterRole(t *testing.T) {
 	assert.Contains(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRoleName}, reconciledClusterRole).Error(), "not found")
 }
 
+func TestGenerateUniqueResourceName(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.Equal(t, "argocd-argocd-argocd-application-controller", GenerateUniqueResourceName(common.ArgoCDApplicationControllerComponent, a))
+
+	// long names are truncated deterministically with a hash suffix
+	a.Name = strings.Repeat("a", 40)
+	a.Namespace = strings.Repeat("b", 40)
+	name := GenerateUniqueResourceName(common.ArgoCDApplicationControllerComponent, a)
+	assert.LessOrEqual(t, len(name), 63)
+	assert.Equal(t, name, GenerateUniqueResourceName(common.ArgoCDApplicationControllerComponent, a))
+	assert.NotEqual(t, name, GenerateUniqueResourceName(common.ArgoCDServerComponent, a))
+}
+
 func TestReconcileArgoCD_reconcileRoleForApplicationSourceNamespaces(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	sourceNamespace := "newNamespaceTest"
@@ -226,7 +226,7 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 		}
 		for _, sourceNamespace := range sourceNamespaces {
 			namespace := &corev1.Namespace{}
-			if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sourceNamespace}, namespace); err != nil {
+			if err := r.getNamespace(context.TODO(), sourceNamespace, namespace); err != nil {
 				return err
 			}
 
@@ -395,3 +395,45 @@ func deleteClusterRoleBindings(c client.Client, clusterBindingList *v1.ClusterRo
 	}
 	return nil
 }
+
+// ensureRoleBinding will create the given RoleBinding, or update the subjects of the existing RoleBinding when they
+// differ. As the RoleRef of a RoleBinding is immutable, the existing RoleBinding is recreated when its RoleRef differs.
+func (r *ReconcileArgoCD) ensureRoleBinding(ctx context.Context, roleBinding v1.RoleBinding, cr *argoproj.ArgoCD) error {
+
+	if err := applyReconcilerHook(cr, roleBinding, ""); err != nil {
+		return err
+	}
+
+	existingRoleBinding := v1.RoleBinding{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: roleBinding.Name, Namespace: roleBinding.Namespace}, &existingRoleBinding)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to retrieve rolebinding %s in namespace %s: %w", roleBinding.Name, roleBinding.Namespace, err)
+	}
+
+	if err == nil {
+		if reflect.DeepEqual(roleBinding.RoleRef, existingRoleBinding.RoleRef) {
+			// if the Subjects differ, update the role bindings
+			if !reflect.DeepEqual(roleBinding.Subjects, existingRoleBinding.Subjects) {
+				existingRoleBinding.Subjects = roleBinding.Subjects
+				if err := r.Client.Update(ctx, &existingRoleBinding); err != nil {
+					return fmt.Errorf("failed to update rolebinding %s in namespace %s: %w", roleBinding.Name, roleBinding.Namespace, err)
+				}
+				log.Info(fmt.Sprintf("rolebinding %s update successfully for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, roleBinding.Namespace))
+			}
+			return nil
+		}
+
+		// if the RoleRef changes, delete the existing role binding and create a new one
+		if err := r.Client.Delete(ctx, &existingRoleBinding); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete rolebinding %s in namespace %s: %w", roleBinding.Name, roleBinding.Namespace, err)
+		}
+		log.Info(fmt.Sprintf("rolebinding %s deleted to update its roleRef for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, roleBinding.Namespace))
+	}
+
+	if err := r.Client.Create(ctx, &roleBinding); err != nil {
+		return fmt.Errorf("failed to create rolebinding %s in namespace %s: %w", roleBinding.Name, roleBinding.Namespace, err)
+	}
+
+	log.Info(fmt.Sprintf("rolebinding %s created successfully for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, roleBinding.Namespace))
+	return nil
+}
@@ -12,6 +12,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
@@ -271,3 +273,75 @@ func TestReconcileArgoCD_reconcileRoleBinding_forSourceNamespaces(t *testing.T)
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: expectedName, Namespace: sourceNamespace}, roleBinding))
 
 }
+
+func TestReconcileArgoCD_ensureRoleBinding(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	deletes := 0
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if rb, ok := obj.(*rbacv1.RoleBinding); ok {
+					// the API server rejects any change of the RoleRef of a RoleBinding
+					existing := &rbacv1.RoleBinding{}
+					if err := client.Get(ctx, types.NamespacedName{Name: rb.Name, Namespace: rb.Namespace}, existing); err != nil {
+						return err
+					}
+					if existing.RoleRef != rb.RoleRef {
+						return fmt.Errorf("cannot change roleRef")
+					}
+				}
+				return client.Update(ctx, obj, opts...)
+			},
+			Delete: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if _, ok := obj.(*rbacv1.RoleBinding); ok {
+					deletes++
+				}
+				return client.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	newBinding := func(roleName string, subjects ...string) rbacv1.RoleBinding {
+		rb := rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-binding", Namespace: a.Namespace},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     roleName,
+			},
+		}
+		for _, subject := range subjects {
+			rb.Subjects = append(rb.Subjects, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: subject, Namespace: a.Namespace})
+		}
+		return rb
+	}
+
+	existing := &rbacv1.RoleBinding{}
+	getBinding := func() {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "example-binding", Namespace: a.Namespace}, existing))
+	}
+
+	// created when absent
+	assert.NoError(t, r.ensureRoleBinding(context.TODO(), newBinding("role-a", "sa-a"), a))
+	getBinding()
+	assert.Equal(t, "role-a", existing.RoleRef.Name)
+
+	// updated in place when only the subjects differ
+	assert.NoError(t, r.ensureRoleBinding(context.TODO(), newBinding("role-a", "sa-a", "sa-b"), a))
+	getBinding()
+	assert.Len(t, existing.Subjects, 2)
+	assert.Equal(t, 0, deletes)
+
+	// deleted and recreated when the RoleRef differs
+	assert.NoError(t, r.ensureRoleBinding(context.TODO(), newBinding("role-b", "sa-a"), a))
+	getBinding()
+	assert.Equal(t, "role-b", existing.RoleRef.Name)
+	assert.Len(t, existing.Subjects, 1)
+	assert.Equal(t, 1, deletes)
+}
@@ -17,6 +17,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -88,42 +89,88 @@ func (r *ReconcileArgoCD) reconcileGrafanaService(cr *argoproj.ArgoCD) error {
 // reconcileMetricsService will ensure that the Service for the Argo CD application controller metrics is present.
 func (r *ReconcileArgoCD) reconcileMetricsService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("metrics", "metrics", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		// Service found, do nothing
-		return nil
-	}
 
-	svc.Spec.Selector = map[string]string{
+	selector := map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("application-controller", cr),
 	}
-
-	svc.Spec.Ports = []corev1.ServicePort{
+	ports := []corev1.ServicePort{
 		{
-			Name:       "metrics",
-			Port:       8082,
+			Name:       getMetricsServicePortName(cr),
+			Port:       getMetricsServicePort(cr),
 			Protocol:   corev1.ProtocolTCP,
 			TargetPort: intstr.FromInt(8082),
 		},
 	}
 
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if !cr.Spec.Controller.IsEnabled() {
+			// Service exists but the Application Controller has been disabled, delete the Service
+			return r.Client.Delete(context.TODO(), svc)
+		}
+		changed := false
+		if !reflect.DeepEqual(svc.Spec.Selector, selector) {
+			svc.Spec.Selector = selector
+			changed = true
+		}
+		if !reflect.DeepEqual(svc.Spec.Ports, ports) {
+			svc.Spec.Ports = ports
+			changed = true
+		}
+		for key, value := range cr.Spec.Controller.MetricsServiceAnnotations {
+			if svc.Annotations[key] != value {
+				svc.Annotations = argoutil.AppendStringMap(svc.Annotations, map[string]string{key: value})
+				changed = true
+			}
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), svc)
+		}
+		return nil // Service found with nothing to do, move along...
+	}
+
+	if !cr.Spec.Controller.IsEnabled() {
+		return nil // Application Controller not enabled, do nothing.
+	}
+
+	svc.Annotations = argoutil.AppendStringMap(svc.Annotations, cr.Spec.Controller.MetricsServiceAnnotations)
+	svc.Spec.Selector = selector
+	svc.Spec.Ports = ports
+
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
 	}
 	return r.Client.Create(context.TODO(), svc)
 }
 
+// getMetricsServicePort will return the port of the Service exposing the metrics of the Application Controller.
+func getMetricsServicePort(cr *argoproj.ArgoCD) int32 {
+	if cr.Spec.Controller.MetricsServicePort > 0 {
+		return cr.Spec.Controller.MetricsServicePort
+	}
+	return common.ArgoCDDefaultApplicationControllerMetricsPort
+}
+
+// getMetricsServicePortName will return the name of the port of the Service exposing the metrics of the
+// Application Controller.
+func getMetricsServicePortName(cr *argoproj.ArgoCD) string {
+	if cr.Spec.Controller.MetricsServicePortName != "" {
+		return cr.Spec.Controller.MetricsServicePortName
+	}
+	return common.ArgoCDKeyMetrics
+}
+
 // reconcileRedisHAAnnounceServices will ensure that the announce Services are present for Redis when running in HA mode.
 func (r *ReconcileArgoCD) reconcileRedisHAAnnounceServices(cr *argoproj.ArgoCD) error {
 	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
 		svc := newServiceWithSuffix(fmt.Sprintf("redis-ha-announce-%d", i), "redis", cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+			if !cr.Spec.HA.Enabled || !isLocalRedisEnabled(cr) {
 				return r.Client.Delete(context.TODO(), svc)
 			}
 			return nil // Service found, do nothing
 		}
 
-		if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+		if !cr.Spec.HA.Enabled || !isLocalRedisEnabled(cr) {
 			return nil //return as Ha is not enabled do nothing
 		}
 
@@ -167,13 +214,13 @@ func (r *ReconcileArgoCD) reconcileRedisHAAnnounceServices(cr *argoproj.ArgoCD)
 func (r *ReconcileArgoCD) reconcileRedisHAMasterService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("redis-ha", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+		if !cr.Spec.HA.Enabled || !isLocalRedisEnabled(cr) {
 			return r.Client.Delete(context.TODO(), svc)
 		}
 		return nil // Service found, do nothing
 	}
 
-	if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+	if !cr.Spec.HA.Enabled || !isLocalRedisEnabled(cr) {
 		return nil //return as Ha is not enabled do nothing
 	}
 
@@ -206,7 +253,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyService(cr *argoproj.ArgoCD) erro
 	svc := newServiceWithSuffix("redis-ha-haproxy", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
 
-		if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+		if !cr.Spec.HA.Enabled || !isLocalRedisEnabled(cr) {
 			return r.Client.Delete(context.TODO(), svc)
 		}
 
@@ -216,7 +263,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyService(cr *argoproj.ArgoCD) erro
 		return nil // Service found, do nothing
 	}
 
-	if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+	if !cr.Spec.HA.Enabled || !isLocalRedisEnabled(cr) {
 		return nil //return as Ha is not enabled do nothing
 	}
 
@@ -258,12 +305,56 @@ func (r *ReconcileArgoCD) reconcileRedisHAServices(cr *argoproj.ArgoCD) error {
 	return nil
 }
 
+// reconcileRedisMetricsService will ensure that the Service for the Redis metrics exporter is present when the
+// monitoring of Redis is enabled.
+func (r *ReconcileArgoCD) reconcileRedisMetricsService(cr *argoproj.ArgoCD) error {
+	svc := newServiceWithSuffix("redis-metrics", "redis", cr)
+
+	selector := map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix("redis", cr),
+	}
+	if cr.Spec.HA.Enabled {
+		selector[common.ArgoCDKeyName] = nameWithSuffix("redis-ha", cr)
+	}
+
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if !isLocalRedisEnabled(cr) || !isRedisMonitoringEnabled(cr) {
+			return r.Client.Delete(context.TODO(), svc)
+		}
+		if !reflect.DeepEqual(svc.Spec.Selector, selector) {
+			// HA has been enabled or disabled, export the metrics of the new Redis pods
+			svc.Spec.Selector = selector
+			return r.Client.Update(context.TODO(), svc)
+		}
+		return nil // Service found, do nothing
+	}
+
+	if !isLocalRedisEnabled(cr) || !isRedisMonitoringEnabled(cr) {
+		return nil
+	}
+
+	svc.Spec.Selector = selector
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultRedisExporterPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultRedisExporterPort),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), svc)
+}
+
 // reconcileRedisService will ensure that the Service for Redis is present.
 func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("redis", "redis", cr)
 
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		if !cr.Spec.Redis.IsEnabled() {
+		if !isLocalRedisEnabled(cr) {
 			return r.Client.Delete(context.TODO(), svc)
 		}
 		if ensureAutoTLSAnnotation(svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS()) {
@@ -275,7 +366,7 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 		return nil // Service found, do nothing
 	}
 
-	if cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+	if cr.Spec.HA.Enabled || !isLocalRedisEnabled(cr) {
 		return nil //return as Ha is enabled do nothing
 	}
 
@@ -294,6 +385,10 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 		},
 	}
 
+	if err := applyReconcilerHook(cr, svc, ""); err != nil {
+		return err
+	}
+
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
 	}
@@ -378,6 +473,10 @@ func (r *ReconcileArgoCD) reconcileRepoService(cr *argoproj.ArgoCD) error {
 		},
 	}
 
+	if err := applyReconcilerHook(cr, svc, ""); err != nil {
+		return err
+	}
+
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
 	}
@@ -449,6 +548,10 @@ func (r *ReconcileArgoCD) reconcileServerService(cr *argoproj.ArgoCD) error {
 
 	svc.Spec.Type = getArgoServerServiceType(cr)
 
+	if err := applyReconcilerHook(cr, svc, ""); err != nil {
+		return err
+	}
+
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
 	}
@@ -482,6 +585,11 @@ func (r *ReconcileArgoCD) reconcileServices(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	err = r.reconcileRedisMetricsService(cr)
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcileRepoService(cr)
 	if err != nil {
 		return err
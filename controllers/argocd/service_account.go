@@ -56,6 +56,34 @@ func getServiceAccountName(crName, name string) string {
 	return fmt.Sprintf("%s-%s", crName, name)
 }
 
+// getServiceAccountAnnotations returns the user provided annotations for the service account of the given component.
+func getServiceAccountAnnotations(name string, cr *argoproj.ArgoCD) map[string]string {
+	switch name {
+	case common.ArgoCDApplicationControllerComponent:
+		return cr.Spec.Controller.ServiceAccountAnnotations
+	case common.ArgoCDServerComponent:
+		return cr.Spec.Server.ServiceAccountAnnotations
+	}
+	return nil
+}
+
+// applyServiceAccountAnnotations adds the given annotations to the service account, overwriting any drifted values.
+// Annotations not present in the given map are left untouched. It returns true if the service account was changed.
+func applyServiceAccountAnnotations(sa *corev1.ServiceAccount, annotations map[string]string) bool {
+	changed := false
+	for key, value := range annotations {
+		if current, ok := sa.Annotations[key]; ok && current == value {
+			continue
+		}
+		if sa.Annotations == nil {
+			sa.Annotations = make(map[string]string)
+		}
+		sa.Annotations[key] = value
+		changed = true
+	}
+	return changed
+}
+
 // reconcileServiceAccounts will ensure that all ArgoCD Service Accounts are configured.
 func (r *ReconcileArgoCD) reconcileServiceAccounts(cr *argoproj.ArgoCD) error {
 	params := getPolicyRuleList(r.Client)
@@ -117,9 +145,14 @@ func (r *ReconcileArgoCD) reconcileServiceAccount(name string, cr *argoproj.Argo
 			log.Info("deleting the existing Dex service account because dex uninstallation requested")
 			return sa, r.Client.Delete(context.TODO(), sa)
 		}
+		if applyServiceAccountAnnotations(sa, getServiceAccountAnnotations(name, cr)) {
+			log.Info(fmt.Sprintf("updating annotations of serviceaccount %s for Argo CD instance %s in namespace %s", sa.Name, cr.Name, cr.Namespace))
+			return sa, r.Client.Update(context.TODO(), sa)
+		}
 		return sa, nil
 	}
 
+	applyServiceAccountAnnotations(sa, getServiceAccountAnnotations(name, cr))
 	if err := controllerutil.SetControllerReference(cr, sa, r.Scheme); err != nil {
 		return nil, err
 	}
@@ -29,6 +29,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 func TestReconcileArgoCD_reconcileServiceAccountPermissions(t *testing.T) {
@@ -166,3 +167,43 @@ func testRules() []v1.PolicyRule {
 		},
 	}
 }
+
+func TestReconcileArgoCD_reconcileServiceAccount_annotations(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.Server.ServiceAccountAnnotations = map[string]string{
+		"iam.gke.io/gcp-service-account": "argocd-server@project.iam.gserviceaccount.com",
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	_, err := r.reconcileServiceAccount(common.ArgoCDServerComponent, a)
+	assert.NoError(t, err)
+
+	sa := &corev1.ServiceAccount{}
+	key := types.NamespacedName{Name: "argocd-argocd-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, sa))
+	assert.Equal(t, "argocd-server@project.iam.gserviceaccount.com", sa.Annotations["iam.gke.io/gcp-service-account"])
+
+	// drifted annotation should be reconciled back, unrelated annotations are kept
+	sa.Annotations["iam.gke.io/gcp-service-account"] = "other@project.iam.gserviceaccount.com"
+	sa.Annotations["example.com/unmanaged"] = "true"
+	assert.NoError(t, r.Client.Update(context.TODO(), sa))
+
+	_, err = r.reconcileServiceAccount(common.ArgoCDServerComponent, a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), key, sa))
+	assert.Equal(t, "argocd-server@project.iam.gserviceaccount.com", sa.Annotations["iam.gke.io/gcp-service-account"])
+	assert.Equal(t, "true", sa.Annotations["example.com/unmanaged"])
+
+	// the application controller service account is not affected by server annotations
+	_, err = r.reconcileServiceAccount(common.ArgoCDApplicationControllerComponent, a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-application-controller", Namespace: a.Namespace}, sa))
+	assert.Empty(t, sa.Annotations)
+}
@@ -1,10 +1,19 @@
 package argocd
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
 )
 
@@ -50,3 +59,131 @@ func TestEnsureAutoTLSAnnotation(t *testing.T) {
 		assert.Equal(t, ok, false)
 	})
 }
+
+func TestReconcileArgoCD_reconcileMetricsService_customPort(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.MetricsServicePort = 9090
+		a.Spec.Controller.MetricsServicePortName = "http-metrics"
+		a.Spec.Controller.MetricsServiceAnnotations = map[string]string{"mesh.example.com/scrape": "true"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileMetricsService(a))
+
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-metrics", Namespace: a.Namespace}, svc))
+	assert.Equal(t, []corev1.ServicePort{
+		{
+			Name:       "http-metrics",
+			Port:       9090,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(8082),
+		},
+	}, svc.Spec.Ports)
+	assert.Equal(t, "true", svc.Annotations["mesh.example.com/scrape"])
+}
+
+func TestReconcileArgoCD_reconcileMetricsService_drift(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.MetricsServiceAnnotations = map[string]string{"mesh.example.com/scrape": "true"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileMetricsService(a))
+
+	// modify the service externally
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-metrics", Namespace: a.Namespace}, svc))
+	svc.Spec.Ports[0].Port = 1234
+	svc.Spec.Ports[0].Name = "other"
+	svc.Spec.Selector = map[string]string{common.ArgoCDKeyName: "other"}
+	svc.Annotations["mesh.example.com/scrape"] = "false"
+	svc.Annotations["example.com/unmanaged"] = "kept"
+	assert.NoError(t, r.Client.Update(context.TODO(), svc))
+
+	assert.NoError(t, r.reconcileMetricsService(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-metrics", Namespace: a.Namespace}, svc))
+	assert.Equal(t, int32(8082), svc.Spec.Ports[0].Port)
+	assert.Equal(t, "metrics", svc.Spec.Ports[0].Name)
+	assert.Equal(t, map[string]string{common.ArgoCDKeyName: "argocd-application-controller"}, svc.Spec.Selector)
+	assert.Equal(t, "true", svc.Annotations["mesh.example.com/scrape"])
+	assert.Equal(t, "kept", svc.Annotations["example.com/unmanaged"])
+}
+
+func TestReconcileArgoCD_reconcileMetricsService_controllerDisabled(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileMetricsService(a))
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-metrics", Namespace: a.Namespace}, svc))
+
+	a.Spec.Controller.Enabled = boolPtr(false)
+	assert.NoError(t, r.reconcileMetricsService(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-metrics", Namespace: a.Namespace}, svc)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// the service is not recreated while the Application Controller is disabled
+	assert.NoError(t, r.reconcileMetricsService(a))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-metrics", Namespace: a.Namespace}, svc)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileServices_hook(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	defer resetHooks()()
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	Register(func(cr *argoproj.ArgoCD, v interface{}, s string) error {
+		if svc, ok := v.(*corev1.Service); ok {
+			svc.Annotations = map[string]string{"example.com/hook": "applied"}
+		}
+		return nil
+	})
+
+	assert.NoError(t, r.reconcileRedisService(a))
+	assert.NoError(t, r.reconcileRepoService(a))
+	assert.NoError(t, r.reconcileServerService(a))
+
+	for _, name := range []string{"argocd-redis", "argocd-repo-server", "argocd-server"} {
+		svc := &corev1.Service{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, svc))
+		assert.Equal(t, "applied", svc.Annotations["example.com/hook"], name)
+	}
+
+	// a failing hook prevents the service from being created
+	assert.NoError(t, r.Client.Delete(context.TODO(), newServiceWithSuffix("server", "server", a)))
+	Register(testErrorHook)
+	assert.Equal(t, errMsg, r.reconcileServerService(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, &corev1.Service{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
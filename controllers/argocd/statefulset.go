@@ -81,6 +81,7 @@ func newStatefulSetWithName(name string, component string, cr *argoproj.ArgoCD)
 		ss.Spec.Template.Spec.NodeSelector = argoutil.AppendStringMap(ss.Spec.Template.Spec.NodeSelector, cr.Spec.NodePlacement.NodeSelector)
 		ss.Spec.Template.Spec.Tolerations = cr.Spec.NodePlacement.Tolerations
 	}
+	ss.Spec.Template.Spec.HostAliases = cr.Spec.HostAliases
 	ss.Spec.ServiceName = name
 
 	return ss
@@ -91,8 +92,43 @@ func newStatefulSetWithSuffix(suffix string, component string, cr *argoproj.Argo
 	return newStatefulSetWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), component, cr)
 }
 
+// getRedisHAAffinity will return the affinity of the Redis HA pods for the given ArgoCD. Unless configured
+// otherwise, the pods are required to run on distinct nodes.
+func getRedisHAAffinity(cr *argoproj.ArgoCD) *corev1.Affinity {
+	if cr.Spec.HA.RedisAffinity != nil {
+		return cr.Spec.HA.RedisAffinity.DeepCopy()
+	}
+
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				common.ArgoCDKeyName: nameWithSuffix("redis-ha", cr),
+			},
+		},
+		TopologyKey: common.ArgoCDKeyHostname,
+	}
+
+	if cr.Spec.HA.RedisAntiAffinity == argoproj.ArgoCDRedisAntiAffinityPreferred {
+		return &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					PodAffinityTerm: term,
+					Weight:          int32(100),
+				}},
+			},
+		}
+	}
+
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+		},
+	}
+}
+
 func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 	ss := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
+	applyDNSSettings(&ss.Spec.Template.Spec, cr)
 
 	ss.Spec.PodManagementPolicy = appsv1.OrderedReadyPodManagement
 	ss.Spec.Replicas = getRedisHAReplicas(cr)
@@ -113,18 +149,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		},
 	}
 
-	ss.Spec.Template.Spec.Affinity = &corev1.Affinity{
-		PodAntiAffinity: &corev1.PodAntiAffinity{
-			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
-				LabelSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						common.ArgoCDKeyName: nameWithSuffix("redis-ha", cr),
-					},
-				},
-				TopologyKey: common.ArgoCDKeyHostname,
-			}},
-		},
-	}
+	ss.Spec.Template.Spec.Affinity = getRedisHAAffinity(cr)
 
 	f := false
 	ss.Spec.Template.Spec.AutomountServiceAccountToken = &f
@@ -325,6 +350,10 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		},
 	}}
 
+	if isRedisMonitoringEnabled(cr) {
+		ss.Spec.Template.Spec.Containers = append(ss.Spec.Template.Spec.Containers, getRedisExporterContainer(cr, r.redisShouldUseTLS(cr)))
+	}
+
 	var fsGroup int64 = 1000
 	var runAsNonRoot bool = true
 	var runAsUser int64 = 1000
@@ -391,7 +420,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 
 	existing := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !(cr.Spec.HA.Enabled && cr.Spec.Redis.IsEnabled()) {
+		if !(cr.Spec.HA.Enabled && isLocalRedisEnabled(cr)) {
 			// StatefulSet exists but either HA or component enabled flag has been set to false, delete the StatefulSet
 			return r.Client.Delete(context.TODO(), existing)
 		}
@@ -399,7 +428,16 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		desiredImage := getRedisHAContainerImage(cr)
 		changed := false
 		updateNodePlacementStateful(existing, ss, &changed)
+		updateHostAliasesStateful(existing, ss, &changed)
+		updateDNSSettings(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
+		if !reflect.DeepEqual(existing.Spec.Template.Spec.Affinity, ss.Spec.Template.Spec.Affinity) {
+			existing.Spec.Template.Spec.Affinity = ss.Spec.Template.Spec.Affinity
+			changed = true
+		}
 		for i, container := range existing.Spec.Template.Spec.Containers {
+			if container.Name == common.ArgoCDKeyMetrics {
+				continue // the metrics exporter is updated separately
+			}
 			if container.Image != desiredImage {
 				existing.Spec.Template.Spec.Containers[i].Image = getRedisHAContainerImage(cr)
 				existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
@@ -417,6 +455,8 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 			changed = true
 		}
 
+		updateRedisExporterContainer(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -424,7 +464,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		return nil // StatefulSet found, do nothing
 	}
 
-	if !cr.Spec.Redis.IsEnabled() {
+	if !isLocalRedisEnabled(cr) {
 		log.Info("Redis disabled. Skipping starting Redis.") // Redis not enabled, do nothing.
 		return nil
 	}
@@ -672,6 +712,7 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			desiredCommand = append(desiredCommand, "--repo-server-strict-tls")
 		}
 		updateNodePlacementStateful(existing, ss, &changed)
+		updateHostAliasesStateful(existing, ss, &changed)
 		if !reflect.DeepEqual(desiredCommand, existing.Spec.Template.Spec.Containers[0].Command) {
 			existing.Spec.Template.Spec.Containers[0].Command = desiredCommand
 			changed = true
@@ -747,6 +788,14 @@ func (r *ReconcileArgoCD) triggerStatefulSetRollout(sts *appsv1.StatefulSet, key
 	return r.Client.Update(context.TODO(), sts)
 }
 
+// to update hostAliases in reconciler
+func updateHostAliasesStateful(existing *appsv1.StatefulSet, ss *appsv1.StatefulSet, changed *bool) {
+	if !reflect.DeepEqual(existing.Spec.Template.Spec.HostAliases, ss.Spec.Template.Spec.HostAliases) {
+		existing.Spec.Template.Spec.HostAliases = ss.Spec.Template.Spec.HostAliases
+		*changed = true
+	}
+}
+
 // to update nodeSelector and tolerations in reconciler
 func updateNodePlacementStateful(existing *appsv1.StatefulSet, ss *appsv1.StatefulSet, changed *bool) {
 	if !reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, ss.Spec.Template.Spec.NodeSelector) {
@@ -136,6 +136,59 @@ func TestReconcileArgoCD_reconcileRedisStatefulSet_HA_enabled(t *testing.T) {
 	assert.Errorf(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s), "not found")
 }
 
+func TestReconcileArgoCD_reconcileRedisStatefulSet_HA_antiAffinity(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	s := newStatefulSetWithSuffix("redis-ha-server", "redis", a)
+
+	// the pods are required to run on distinct nodes by default
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	antiAffinity := s.Spec.Template.Spec.Affinity.PodAntiAffinity
+	assert.Len(t, antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, 1)
+	assert.Empty(t, antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+
+	// switching to the preferred variant updates the StatefulSet
+	a.Spec.HA.RedisAntiAffinity = argoproj.ArgoCDRedisAntiAffinityPreferred
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	antiAffinity = s.Spec.Template.Spec.Affinity.PodAntiAffinity
+	assert.Empty(t, antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+	assert.Len(t, antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+	preferred := antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0]
+	assert.Equal(t, int32(100), preferred.Weight)
+	assert.Equal(t, common.ArgoCDKeyHostname, preferred.PodAffinityTerm.TopologyKey)
+	assert.Equal(t, "argocd-redis-ha", preferred.PodAffinityTerm.LabelSelector.MatchLabels[common.ArgoCDKeyName])
+
+	// a custom affinity takes precedence
+	a.Spec.HA.RedisAffinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "node-role.kubernetes.io/infra",
+						Operator: corev1.NodeSelectorOpExists,
+					}},
+				}},
+			},
+		},
+	}
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Equal(t, a.Spec.HA.RedisAffinity, s.Spec.Template.Spec.Affinity)
+}
+
 func TestReconcileArgoCD_reconcileApplicationController(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -189,7 +189,10 @@ func (r *ReconcileArgoCD) reconcileStatusApplicationSetController(cr *argoproj.A
 	status := "Unknown"
 
 	deploy := newDeploymentWithSuffix("applicationset-controller", "controller", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+	if cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.IsEnabled() && !IsApplicationSetAPIAvailable() {
+		// the deployment is not reconciled without the Application and ApplicationSet CRDs
+		status = "Failed"
+	} else if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
 		status = "Pending"
 
 		if deploy.Spec.Replicas != nil {
@@ -243,7 +246,7 @@ func (r *ReconcileArgoCD) reconcileStatusPhase(cr *argoproj.ArgoCD) error {
 	var phase string
 
 	if ((!cr.Spec.Controller.IsEnabled() && cr.Status.ApplicationController == "Unknown") || cr.Status.ApplicationController == "Running") &&
-		((!cr.Spec.Redis.IsEnabled() && cr.Status.Redis == "Unknown") || cr.Status.Redis == "Running") &&
+		((!isLocalRedisEnabled(cr) && cr.Status.Redis == "Unknown") || cr.Status.Redis == "Running") &&
 		((!cr.Spec.Repo.IsEnabled() && cr.Status.Repo == "Unknown") || cr.Status.Repo == "Running") &&
 		((!cr.Spec.Server.IsEnabled() && cr.Status.Server == "Unknown") || cr.Status.Server == "Running") {
 		phase = "Available"
@@ -287,10 +290,14 @@ func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoproj.ArgoCD) error {
 			status = "Pending"
 
 			if ss.Status.ReadyReplicas == *ss.Spec.Replicas {
-				status = "Running"
+				// Redis is only reachable by the other components through the HA proxy
+				haproxy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
+				if argoutil.IsObjectFound(r.Client, cr.Namespace, haproxy.Name, haproxy) &&
+					haproxy.Spec.Replicas != nil && haproxy.Status.ReadyReplicas == *haproxy.Spec.Replicas {
+					status = "Running"
+				}
 			}
 		}
-		// TODO: Add check for HA proxy deployment here as well?
 	}
 
 	if cr.Status.Redis != status {
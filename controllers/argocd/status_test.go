@@ -10,6 +10,7 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -317,7 +318,109 @@ func TestReconcileArgoCD_reconcileStatusApplicationSetController(t *testing.T) {
 	assert.Equal(t, "Unknown", a.Status.ApplicationSetController)
 
 	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
-	assert.NoError(t, r.reconcileApplicationSetController(a))
+	assert.NoError(t, r.reconcileApplicationSetController(context.TODO(), a))
 	assert.NoError(t, r.reconcileStatusApplicationSetController(a))
 	assert.Equal(t, "Pending", a.Status.ApplicationSetController)
 }
+
+func TestReconcileArgoCD_reconcileStatusPhase_remoteRedis(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	remote := "redis.example.com:6379"
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Redis.Remote = &remote
+	})
+	a.Status.ApplicationController = "Running"
+	a.Status.Repo = "Running"
+	a.Status.Server = "Running"
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the operator doesn't run redis, so its status isn't known
+	assert.NoError(t, r.reconcileStatusRedis(a))
+	assert.Equal(t, "Unknown", a.Status.Redis)
+
+	assert.NoError(t, r.reconcileStatusPhase(a))
+	assert.Equal(t, "Available", a.Status.Phase)
+}
+
+func TestReconcileArgoCD_reconcileStatusPhase_redisNotReady(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	tests := []struct {
+		name      string
+		haEnabled bool
+	}{
+		{name: "standalone redis"},
+		{name: "redis ha", haEnabled: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.HA.Enabled = test.haEnabled
+			})
+			a.Status.ApplicationController = "Running"
+			a.Status.Repo = "Running"
+			a.Status.Server = "Running"
+
+			resObjs := []client.Object{a}
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			assert.NoError(t, r.reconcileRedisDeployment(a, false))
+			assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+			assert.NoError(t, r.reconcileRedisStatefulSet(a))
+
+			setReady := func(obj client.Object) {
+				assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj))
+				switch o := obj.(type) {
+				case *appsv1.Deployment:
+					// replicas are defaulted by the API server
+					if o.Spec.Replicas == nil {
+						replicas := int32(1)
+						o.Spec.Replicas = &replicas
+						assert.NoError(t, r.Client.Update(context.TODO(), o))
+					}
+					o.Status.ReadyReplicas = *o.Spec.Replicas
+				case *appsv1.StatefulSet:
+					o.Status.ReadyReplicas = *o.Spec.Replicas
+				}
+				assert.NoError(t, r.Client.Status().Update(context.TODO(), obj))
+			}
+
+			// the redis pods aren't ready yet
+			assert.NoError(t, r.reconcileStatusRedis(a))
+			assert.Equal(t, "Pending", a.Status.Redis)
+			assert.NoError(t, r.reconcileStatusPhase(a))
+			assert.Equal(t, "Pending", a.Status.Phase)
+
+			if test.haEnabled {
+				setReady(newStatefulSetWithSuffix("redis-ha-server", "redis", a))
+
+				// the HA proxy isn't ready yet
+				assert.NoError(t, r.reconcileStatusRedis(a))
+				assert.Equal(t, "Pending", a.Status.Redis)
+
+				setReady(newDeploymentWithSuffix("redis-ha-haproxy", "redis", a))
+			} else {
+				d := newDeploymentWithSuffix("redis", "redis", a)
+				setReady(d)
+				assert.NotNil(t, d.Spec.Template.Spec.Containers[0].ReadinessProbe)
+			}
+
+			assert.NoError(t, r.reconcileStatusRedis(a))
+			assert.Equal(t, "Running", a.Status.Redis)
+			assert.NoError(t, r.reconcileStatusPhase(a))
+			assert.Equal(t, "Available", a.Status.Phase)
+		})
+	}
+}
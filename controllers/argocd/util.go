@@ -18,14 +18,20 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
+	"runtime/debug"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 
@@ -57,7 +63,9 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -67,12 +75,32 @@ import (
 
 const (
 	grafanaDeprecatedWarning = "Warning: grafana field is deprecated from ArgoCD: field will be ignored."
+
+	// clusterVersionCacheTTL is how long the OpenShift cluster version is cached before it is read again.
+	clusterVersionCacheTTL = 10 * time.Minute
 )
 
 var (
 	versionAPIFound = false
+
+	// clusterVersionCache holds the last OpenShift cluster version read by getClusterVersion, so that
+	// every reconcile doesn't have to read the ClusterVersion again.
+	clusterVersionCache = struct {
+		sync.Mutex
+		version string
+		expiry  time.Time
+	}{}
 )
 
+// resetClusterVersionCache drops the cached OpenShift cluster version, so that the next getClusterVersion reads it
+// again. The cache is shared by all clients of the process.
+func resetClusterVersionCache() {
+	clusterVersionCache.Lock()
+	defer clusterVersionCache.Unlock()
+	clusterVersionCache.version = ""
+	clusterVersionCache.expiry = time.Time{}
+}
+
 // IsVersionAPIAvailable returns true if the version api is present
 func IsVersionAPIAvailable() bool {
 	return versionAPIFound
@@ -166,27 +194,64 @@ func getArgoApplicationControllerCommand(cr *argoproj.ArgoCD, useTLSForRedis boo
 	return cmd
 }
 
-// getArgoContainerImage will return the container image for ArgoCD.
-func getArgoContainerImage(cr *argoproj.ArgoCD) string {
-	defaultTag, defaultImg := false, false
-	img := cr.Spec.Image
+// resolveImage will return the container image reference for a component.
+//
+// The image and the tag are resolved independently, in this order of preference:
+//
+// 1. the component specific value from the Spec (e.g. spec.applicationSet.image).
+// 2. the ArgoCD wide value from the Spec (e.g. spec.image).
+// 3. the operator default.
+//
+// If both the image and the tag fall back to the operator defaults, the image
+// reference from the envVarName environment variable is used when it is set.
+// Otherwise the registry of a default image is replaced with the registry from
+// the ARGOCD_IMAGE_REGISTRY environment variable, when it is set.
+func resolveImage(componentImage, componentVersion, crImage, crVersion, defaultImage, defaultVersion, envVarName string) string {
+	defaultImg, defaultTag := false, false
+
+	img := componentImage
 	if img == "" {
-		img = common.ArgoCDDefaultArgoImage
+		img = crImage
+	}
+	if img == "" {
+		img = defaultImage
 		defaultImg = true
 	}
 
-	tag := cr.Spec.Version
+	tag := componentVersion
 	if tag == "" {
-		tag = common.ArgoCDDefaultArgoVersion
+		tag = crVersion
+	}
+	if tag == "" {
+		tag = defaultVersion
 		defaultTag = true
 	}
-	if e := os.Getenv(common.ArgoCDImageEnvName); e != "" && (defaultTag && defaultImg) {
+
+	if e := os.Getenv(envVarName); e != "" && (defaultTag && defaultImg) {
 		return e
 	}
-
+	if registry := strings.TrimSuffix(os.Getenv(common.ArgoCDImageRegistryEnvName), "/"); registry != "" && defaultImg {
+		img = withImageRegistry(img, registry)
+	}
 	return argoutil.CombineImageTag(img, tag)
 }
 
+// withImageRegistry returns the image reference with its registry host replaced by the given registry. An image
+// without a registry host, e.g. "redis", is prefixed with the registry.
+func withImageRegistry(image, registry string) string {
+	if host, path, found := strings.Cut(image, "/"); found &&
+		(strings.ContainsAny(host, ".:") || host == "localhost") {
+		image = path
+	}
+	return registry + "/" + image
+}
+
+// getArgoContainerImage will return the container image for ArgoCD.
+func getArgoContainerImage(cr *argoproj.ArgoCD) string {
+	return resolveImage("", "", cr.Spec.Image, cr.Spec.Version,
+		common.ArgoCDDefaultArgoImage, common.ArgoCDDefaultArgoVersion, common.ArgoCDImageEnvName)
+}
+
 // getRepoServerContainerImage will return the container image for the Repo server.
 //
 // There are three possible options for configuring the image, and this is the
@@ -199,22 +264,8 @@ func getArgoContainerImage(cr *argoproj.ArgoCD) string {
 // 3. the default is configured in common.ArgoCDDefaultRepoServerVersion and
 // common.ArgoCDDefaultRepoServerImage.
 func getRepoServerContainerImage(cr *argoproj.ArgoCD) string {
-	defaultImg, defaultTag := false, false
-	img := cr.Spec.Repo.Image
-	if img == "" {
-		img = common.ArgoCDDefaultArgoImage
-		defaultImg = true
-	}
-
-	tag := cr.Spec.Repo.Version
-	if tag == "" {
-		tag = common.ArgoCDDefaultArgoVersion
-		defaultTag = true
-	}
-	if e := os.Getenv(common.ArgoCDImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
-	}
-	return argoutil.CombineImageTag(img, tag)
+	return resolveImage(cr.Spec.Repo.Image, cr.Spec.Repo.Version, "", "",
+		common.ArgoCDDefaultArgoImage, common.ArgoCDDefaultArgoVersion, common.ArgoCDImageEnvName)
 }
 
 // getArgoRepoResources will return the ResourceRequirements for the Argo CD Repo server container.
@@ -367,40 +418,109 @@ func getRedisConf(useTLSForRedis bool) string {
 
 // getRedisContainerImage will return the container image for the Redis server.
 func getRedisContainerImage(cr *argoproj.ArgoCD) string {
-	defaultImg, defaultTag := false, false
-	img := cr.Spec.Redis.Image
-	if img == "" {
-		img = common.ArgoCDDefaultRedisImage
-		defaultImg = true
-	}
-	tag := cr.Spec.Redis.Version
-	if tag == "" {
-		tag = common.ArgoCDDefaultRedisVersion
-		defaultTag = true
+	return resolveImage(cr.Spec.Redis.Image, cr.Spec.Redis.Version, "", "",
+		common.ArgoCDDefaultRedisImage, common.ArgoCDDefaultRedisVersion, common.ArgoCDRedisImageEnvName)
+}
+
+// isRedisMonitoringEnabled returns true if the metrics of Redis should be exported.
+func isRedisMonitoringEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Redis.Monitoring != nil && cr.Spec.Redis.Monitoring.Enabled
+}
+
+// getRedisExporterContainerImage will return the container image for the Redis metrics exporter.
+func getRedisExporterContainerImage(cr *argoproj.ArgoCD) string {
+	img, tag := "", ""
+	if cr.Spec.Redis.Monitoring != nil {
+		img = cr.Spec.Redis.Monitoring.Image
+		tag = cr.Spec.Redis.Monitoring.Version
+	}
+	return resolveImage(img, tag, "", "",
+		common.ArgoCDDefaultRedisExporterImage, common.ArgoCDDefaultRedisExporterVersion, common.ArgoCDRedisExporterImageEnvName)
+}
+
+// getRedisExporterContainer will return the sidecar container exporting the metrics of the Redis server
+// running in the same pod.
+func getRedisExporterContainer(cr *argoproj.ArgoCD, useTLS bool) corev1.Container {
+	env := []corev1.EnvVar{{
+		Name:  "REDIS_ADDR",
+		Value: fmt.Sprintf("redis://localhost:%d", common.ArgoCDDefaultRedisPort),
+	}}
+	if useTLS {
+		// the certificate of Redis is issued for its service, not for localhost
+		env = []corev1.EnvVar{
+			{
+				Name:  "REDIS_ADDR",
+				Value: fmt.Sprintf("rediss://localhost:%d", common.ArgoCDDefaultRedisPort),
+			},
+			{
+				Name:  "REDIS_EXPORTER_SKIP_TLS_VERIFICATION",
+				Value: "true",
+			},
+		}
 	}
-	if e := os.Getenv(common.ArgoCDRedisImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+
+	return corev1.Container{
+		Env:             env,
+		Image:           getRedisExporterContainerImage(cr),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Name:            common.ArgoCDKeyMetrics,
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: common.ArgoCDDefaultRedisExporterPort,
+				Name:          common.ArgoCDKeyMetrics,
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{
+					"ALL",
+				},
+			},
+			RunAsNonRoot: boolPtr(true),
+		},
 	}
-	return argoutil.CombineImageTag(img, tag)
 }
 
-// getRedisHAContainerImage will return the container image for the Redis server in HA mode.
-func getRedisHAContainerImage(cr *argoproj.ArgoCD) string {
-	defaultImg, defaultTag := false, false
-	img := cr.Spec.Redis.Image
-	if img == "" {
-		img = common.ArgoCDDefaultRedisImage
-		defaultImg = true
+// updateRedisExporterContainer will make the Redis metrics exporter sidecar of the existing containers match the
+// desired ones, adding or removing it when the monitoring of Redis has been enabled or disabled.
+func updateRedisExporterContainer(existing *corev1.PodSpec, desired *corev1.PodSpec, changed *bool) {
+	var existingExporter, desiredExporter *corev1.Container
+	containers := make([]corev1.Container, 0, len(existing.Containers))
+	for i := range existing.Containers {
+		if existing.Containers[i].Name == common.ArgoCDKeyMetrics {
+			existingExporter = &existing.Containers[i]
+			continue
+		}
+		containers = append(containers, existing.Containers[i])
 	}
-	tag := cr.Spec.Redis.Version
-	if tag == "" {
-		tag = common.ArgoCDDefaultRedisVersionHA
-		defaultTag = true
+	for i := range desired.Containers {
+		if desired.Containers[i].Name == common.ArgoCDKeyMetrics {
+			desiredExporter = &desired.Containers[i]
+		}
 	}
-	if e := os.Getenv(common.ArgoCDRedisHAImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
+
+	switch {
+	case existingExporter == nil && desiredExporter == nil:
+		return
+	case existingExporter != nil && desiredExporter != nil:
+		if existingExporter.Image == desiredExporter.Image && reflect.DeepEqual(existingExporter.Env, desiredExporter.Env) {
+			return
+		}
+		existingExporter.Image = desiredExporter.Image
+		existingExporter.Env = desiredExporter.Env
+	case desiredExporter != nil:
+		existing.Containers = append(existing.Containers, *desiredExporter)
+	default:
+		existing.Containers = containers
 	}
-	return argoutil.CombineImageTag(img, tag)
+	*changed = true
+}
+
+// getRedisHAContainerImage will return the container image for the Redis server in HA mode.
+func getRedisHAContainerImage(cr *argoproj.ArgoCD) string {
+	return resolveImage(cr.Spec.Redis.Image, cr.Spec.Redis.Version, "", "",
+		common.ArgoCDDefaultRedisImage, common.ArgoCDDefaultRedisVersionHA, common.ArgoCDRedisHAImageEnvName)
 }
 
 // getRedisHAProxyAddress will return the Redis HA Proxy service address for the given ArgoCD.
@@ -410,24 +530,8 @@ func getRedisHAProxyAddress(cr *argoproj.ArgoCD) string {
 
 // getRedisHAProxyContainerImage will return the container image for the Redis HA Proxy.
 func getRedisHAProxyContainerImage(cr *argoproj.ArgoCD) string {
-	defaultImg, defaultTag := false, false
-	img := cr.Spec.HA.RedisProxyImage
-	if len(img) <= 0 {
-		img = common.ArgoCDDefaultRedisHAProxyImage
-		defaultImg = true
-	}
-
-	tag := cr.Spec.HA.RedisProxyVersion
-	if len(tag) <= 0 {
-		tag = common.ArgoCDDefaultRedisHAProxyVersion
-		defaultTag = true
-	}
-
-	if e := os.Getenv(common.ArgoCDRedisHAProxyImageEnvName); e != "" && (defaultTag && defaultImg) {
-		return e
-	}
-
-	return argoutil.CombineImageTag(img, tag)
+	return resolveImage(cr.Spec.HA.RedisProxyImage, cr.Spec.HA.RedisProxyVersion, "", "",
+		common.ArgoCDDefaultRedisHAProxyImage, common.ArgoCDDefaultRedisHAProxyVersion, common.ArgoCDRedisHAProxyImageEnvName)
 }
 
 // getRedisInitScript will load the redis init script from a template on disk for the given ArgoCD.
@@ -480,9 +584,74 @@ func getRedisHAProxyScript(cr *argoproj.ArgoCD) string {
 	return script
 }
 
+// mergeVolumes returns the volumes with the overrides merged in. An override replaces the volume with the same
+// name in place, the remaining overrides are appended in order.
+func mergeVolumes(volumes []corev1.Volume, overrides []corev1.Volume) []corev1.Volume {
+	merged := append([]corev1.Volume{}, volumes...)
+	for _, override := range overrides {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == override.Name {
+				merged[i] = override
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, override)
+		}
+	}
+	return merged
+}
+
+// mergeVolumeMounts returns the volumeMounts with the overrides merged in. An override replaces the volumeMount with
+// the same mount path in place, the remaining overrides are appended in order.
+func mergeVolumeMounts(mounts []corev1.VolumeMount, overrides []corev1.VolumeMount) []corev1.VolumeMount {
+	merged := append([]corev1.VolumeMount{}, mounts...)
+	for _, override := range overrides {
+		replaced := false
+		for i := range merged {
+			if merged[i].MountPath == override.MountPath {
+				merged[i] = override
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, override)
+		}
+	}
+	return merged
+}
+
+// getDefaultResources will return the default ResourceRequirements of a component container, used when the spec
+// does not set any. The defaults can be overridden with a JSON encoded ResourceRequirements in the given environment
+// variable, where an empty object disables them.
+func getDefaultResources(envName, requestCPU, requestMemory, limitCPU, limitMemory string) corev1.ResourceRequirements {
+	if v := os.Getenv(envName); v != "" {
+		resources := corev1.ResourceRequirements{}
+		if err := json.Unmarshal([]byte(v), &resources); err == nil {
+			return resources
+		}
+		log.Info(fmt.Sprintf("invalid value %q for %s, using the default resource requirements", v, envName))
+	}
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(limitCPU),
+			corev1.ResourceMemory: resource.MustParse(limitMemory),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(requestCPU),
+			corev1.ResourceMemory: resource.MustParse(requestMemory),
+		},
+	}
+}
+
 // getRedisResources will return the ResourceRequirements for the Redis container.
 func getRedisResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDRedisDefaultResourcesEnvName,
+		common.ArgoCDDefaultRedisResourceRequestCPU, common.ArgoCDDefaultRedisResourceRequestMemory,
+		common.ArgoCDDefaultRedisResourceLimitCPU, common.ArgoCDDefaultRedisResourceLimitMemory)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.Redis.Resources != nil {
@@ -494,7 +663,9 @@ func getRedisResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 
 // getRedisHAResources will return the ResourceRequirements for the Redis HA.
 func getRedisHAResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
-	resources := corev1.ResourceRequirements{}
+	resources := getDefaultResources(common.ArgoCDRedisHADefaultResourcesEnvName,
+		common.ArgoCDDefaultRedisResourceRequestCPU, common.ArgoCDDefaultRedisResourceRequestMemory,
+		common.ArgoCDDefaultRedisResourceLimitCPU, common.ArgoCDDefaultRedisResourceLimitMemory)
 
 	// Allow override of resource requirements from CR
 	if cr.Spec.HA.Resources != nil {
@@ -564,9 +735,54 @@ func getSentinelLivenessScript(useTLSForRedis bool) string {
 	return conf
 }
 
+// isLocalRedisEnabled returns true if the operator should run Redis for the given ArgoCD, that is Redis is enabled
+// and no valid remote Redis is used.
+func isLocalRedisEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Redis.IsEnabled() && !isRemoteRedis(cr)
+}
+
+// isRemoteRedis returns true if the given ArgoCD uses a remote Redis with a valid address.
+func isRemoteRedis(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Redis.IsRemote() && validateRedisAddress(*cr.Spec.Redis.Remote) == nil
+}
+
+// validateRedisAddress returns an error if the given address is not in the host:port format.
+func validateRedisAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return fmt.Errorf("address %s: missing host", address)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("address %s: invalid port %q", address, port)
+	}
+	return nil
+}
+
+// invalidRemoteRedisReason is the reason of the warning event emitted when the remote Redis address of an ArgoCD is
+// not valid.
+const invalidRemoteRedisReason = "InvalidRemoteRedis"
+
+// validateRemoteRedis emits a warning event when the remote Redis address of the given ArgoCD is not valid, as the
+// components would silently fail to connect to it. The operator managed Redis is used instead.
+func (r *ReconcileArgoCD) validateRemoteRedis(cr *argoproj.ArgoCD) error {
+	if !cr.Spec.Redis.IsRemote() {
+		r.clearWarningEvent(cr, invalidRemoteRedisReason)
+		return nil
+	}
+	if err := validateRedisAddress(*cr.Spec.Redis.Remote); err != nil {
+		message := fmt.Sprintf("invalid .spec.redis.remote, the operator managed Redis is used instead: %v", err)
+		return r.emitWarningEvent(cr, invalidRemoteRedisReason, message)
+	}
+	r.clearWarningEvent(cr, invalidRemoteRedisReason)
+	return nil
+}
+
 // getRedisServerAddress will return the Redis service address for the given ArgoCD.
 func getRedisServerAddress(cr *argoproj.ArgoCD) string {
-	if cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" {
+	if isRemoteRedis(cr) {
 		return *cr.Spec.Redis.Remote
 	}
 	if cr.Spec.HA.Enabled {
@@ -599,10 +815,35 @@ func nameWithSuffix(suffix string, cr *argoproj.ArgoCD) string {
 	return fmt.Sprintf("%s-%s", cr.Name, suffix)
 }
 
+// truncateWithHash returns the given name if it is at most maxLen characters long. Longer names are truncated and
+// suffixed with a hash of the full name, so that the result is stable across reconciles and names sharing a long
+// prefix don't collide.
+func truncateWithHash(name string, maxLen int) string {
+	if len(name) <= maxLen {
+		return name
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:8]
+	return strings.TrimRight(name[:maxLen-len(hash)-1], "-.") + "-" + hash
+}
+
 // fqdnServiceRef will return the FQDN referencing a specific service name, as set up by the operator, with the
 // given port.
 func fqdnServiceRef(service string, port int, cr *argoproj.ArgoCD) string {
-	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", nameWithSuffix(service, cr), cr.Namespace, port)
+	return fmt.Sprintf("%s:%d", fqdnServiceName(nameWithSuffix(service, cr), cr.Namespace), port)
+}
+
+// fqdnServiceName will return the FQDN of the given service in the given namespace, using the cluster domain.
+func fqdnServiceName(service string, namespace string) string {
+	return fmt.Sprintf("%s.%s.svc.%s", service, namespace, getClusterDomain())
+}
+
+// getClusterDomain returns the DNS domain of the cluster, set with the CLUSTER_DOMAIN environment variable.
+// Defaults to cluster.local.
+func getClusterDomain() string {
+	if domain := strings.Trim(os.Getenv(common.ArgoCDClusterDomainEnvName), "."); domain != "" {
+		return domain
+	}
+	return common.ArgoCDDefaultClusterDomain
 }
 
 // InspectCluster will verify the availability of extra features available to the cluster, such as Prometheus and
@@ -623,6 +864,10 @@ func InspectCluster() error {
 	if err := verifyVersionAPI(); err != nil {
 		return err
 	}
+
+	if err := verifyApplicationSetAPI(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -689,91 +934,106 @@ func (r *ReconcileArgoCD) redisShouldUseTLS(cr *argoproj.ArgoCD) bool {
 	return false
 }
 
+// reconcileComponent runs the given reconcile function of a component and records its duration in
+// ComponentReconcileTime. A panic in the reconcile function is recovered and returned as an error, so that a bug in
+// a single component fails the reconcile, which is then retried, instead of crashing the operator.
+func reconcileComponent(component string, reconcileFn func() error) (err error) {
+	start := time.Now()
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic while reconciling %s: %v", component, p)
+			log.Error(err, "recovered from panic", "stack", string(debug.Stack()))
+		}
+		ComponentReconcileTime.WithLabelValues(component).Observe(time.Since(start).Seconds())
+	}()
+	return reconcileFn()
+}
+
 // reconcileResources will reconcile common ArgoCD resources.
-func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) reconcileResources(ctx context.Context, cr *argoproj.ArgoCD) error {
 
 	// we reconcile SSO first so that we can catch and throw errors for any illegal SSO configurations right away, and return control from here
 	// preventing dex resources from getting created anyway through the other function calls, effectively bypassing the SSO checks
 	log.Info("reconciling SSO")
-	if err := r.reconcileSSO(cr); err != nil {
+	if err := reconcileComponent("sso", func() error { return r.reconcileSSO(cr) }); err != nil {
 		log.Info(err.Error())
 	}
 
 	log.Info("reconciling status")
-	if err := r.reconcileStatus(cr); err != nil {
+	if err := reconcileComponent("status", func() error { return r.reconcileStatus(cr) }); err != nil {
 		log.Info(err.Error())
 	}
 
 	log.Info("reconciling roles")
-	if err := r.reconcileRoles(cr); err != nil {
+	if err := reconcileComponent("roles", func() error { return r.reconcileRoles(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling rolebindings")
-	if err := r.reconcileRoleBindings(cr); err != nil {
+	if err := reconcileComponent("rolebindings", func() error { return r.reconcileRoleBindings(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling service accounts")
-	if err := r.reconcileServiceAccounts(cr); err != nil {
+	if err := reconcileComponent("serviceaccounts", func() error { return r.reconcileServiceAccounts(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling certificate authority")
-	if err := r.reconcileCertificateAuthority(cr); err != nil {
+	if err := reconcileComponent("certificateauthority", func() error { return r.reconcileCertificateAuthority(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling secrets")
-	if err := r.reconcileSecrets(cr); err != nil {
+	if err := reconcileComponent("secrets", func() error { return r.reconcileSecrets(cr) }); err != nil {
 		return err
 	}
 
 	useTLSForRedis := r.redisShouldUseTLS(cr)
 
 	log.Info("reconciling config maps")
-	if err := r.reconcileConfigMaps(cr, useTLSForRedis); err != nil {
+	if err := reconcileComponent("configmaps", func() error { return r.reconcileConfigMaps(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling services")
-	if err := r.reconcileServices(cr); err != nil {
+	if err := reconcileComponent("services", func() error { return r.reconcileServices(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling deployments")
-	if err := r.reconcileDeployments(cr, useTLSForRedis); err != nil {
+	if err := reconcileComponent("deployments", func() error { return r.reconcileDeployments(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling statefulsets")
-	if err := r.reconcileStatefulSets(cr, useTLSForRedis); err != nil {
+	if err := reconcileComponent("statefulsets", func() error { return r.reconcileStatefulSets(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling autoscalers")
-	if err := r.reconcileAutoscalers(cr); err != nil {
+	if err := reconcileComponent("autoscalers", func() error { return r.reconcileAutoscalers(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling ingresses")
-	if err := r.reconcileIngresses(cr); err != nil {
+	if err := reconcileComponent("ingresses", func() error { return r.reconcileIngresses(cr) }); err != nil {
 		return err
 	}
 
 	if IsRouteAPIAvailable() {
 		log.Info("reconciling routes")
-		if err := r.reconcileRoutes(cr); err != nil {
+		if err := reconcileComponent("routes", func() error { return r.reconcileRoutes(cr) }); err != nil {
 			return err
 		}
 	}
 
 	if IsPrometheusAPIAvailable() {
 		log.Info("reconciling prometheus")
-		if err := r.reconcilePrometheus(cr); err != nil {
+		if err := reconcileComponent("prometheus", func() error { return r.reconcilePrometheus(cr) }); err != nil {
 			return err
 		}
 
@@ -793,19 +1053,23 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
 		if err := r.reconcileServerMetricsServiceMonitor(cr); err != nil {
 			return err
 		}
+
+		if err := r.reconcileRedisServiceMonitor(cr); err != nil {
+			return err
+		}
 	}
 
 	// check ManagedApplicationSetSourceNamespaces for proper cleanup
 	if cr.Spec.ApplicationSet != nil || len(r.ManagedApplicationSetSourceNamespaces) > 0 {
 		log.Info("reconciling ApplicationSet controller")
-		if err := r.reconcileApplicationSetController(cr); err != nil {
+		if err := reconcileComponent("applicationset-controller", func() error { return r.reconcileApplicationSetController(ctx, cr) }); err != nil {
 			return err
 		}
 	}
 
 	if cr.Spec.Notifications.Enabled {
 		log.Info("reconciling Notifications controller")
-		if err := r.reconcileNotificationsController(cr); err != nil {
+		if err := reconcileComponent("notifications-controller", func() error { return r.reconcileNotificationsController(cr) }); err != nil {
 			return err
 		}
 	}
@@ -821,6 +1085,88 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
 	return nil
 }
 
+// emitWarningEvent records a Warning event with the given reason on the ArgoCD instance. The event is only emitted
+// when its message differs from the last one emitted for the same reason, to avoid flooding the instance with
+// identical events on every reconcile.
+func (r *ReconcileArgoCD) emitWarningEvent(cr *argoproj.ArgoCD, reason, message string) error {
+	key := types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}
+	if r.warningEvents[key][reason] == message {
+		return nil
+	}
+
+	log.Info(message)
+	typeMeta := metav1.TypeMeta{Kind: "ArgoCD", APIVersion: argoproj.GroupVersion.String()}
+	if err := argoutil.CreateEvent(r.Client, corev1.EventTypeWarning, "Validating", message, reason, cr.ObjectMeta, typeMeta); err != nil {
+		return err
+	}
+
+	if r.warningEvents == nil {
+		r.warningEvents = make(map[types.NamespacedName]map[string]string)
+	}
+	if r.warningEvents[key] == nil {
+		r.warningEvents[key] = make(map[string]string)
+	}
+	r.warningEvents[key][reason] = message
+	return nil
+}
+
+// clearWarningEvent forgets the last warning event emitted with the given reason, so that it is emitted again
+// should the issue reappear.
+func (r *ReconcileArgoCD) clearWarningEvent(cr *argoproj.ArgoCD, reason string) {
+	delete(r.warningEvents[types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}], reason)
+}
+
+// workloadTerminationTimeout bounds how long the deletion of the cluster resources of a deleted ArgoCD waits for its
+// workloads to terminate.
+const workloadTerminationTimeout = 2 * time.Minute
+
+// deleteWorkloads will delete the Deployments and StatefulSets of the given ArgoCD, and returns true once they, and
+// so their pods, are gone. The workloads are deleted in the foreground, so that they only disappear after their pods.
+func (r *ReconcileArgoCD) deleteWorkloads(cr *argoproj.ArgoCD) (bool, error) {
+	selector, err := argocdInstanceSelector(cr.Name)
+	if err != nil {
+		return false, err
+	}
+	listOptions := []client.ListOption{client.InNamespace(cr.Namespace), client.MatchingLabelsSelector{Selector: selector}}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.Client.List(context.TODO(), deployments, listOptions...); err != nil {
+		return false, fmt.Errorf("failed to list Deployments for %s: %w", cr.Name, err)
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.Client.List(context.TODO(), statefulSets, listOptions...); err != nil {
+		return false, fmt.Errorf("failed to list StatefulSets for %s: %w", cr.Name, err)
+	}
+
+	workloads := []client.Object{}
+	for i := range deployments.Items {
+		workloads = append(workloads, &deployments.Items[i])
+	}
+	for i := range statefulSets.Items {
+		workloads = append(workloads, &statefulSets.Items[i])
+	}
+
+	for _, workload := range workloads {
+		if workload.GetDeletionTimestamp() != nil {
+			continue // already terminating
+		}
+		if err := r.Client.Delete(context.TODO(), workload, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete %s during cleanup: %w", workload.GetName(), err)
+		}
+	}
+
+	if len(workloads) > 0 {
+		// check whether the workloads deleted above are already gone
+		if err := r.Client.List(context.TODO(), deployments, listOptions...); err != nil {
+			return false, fmt.Errorf("failed to list Deployments for %s: %w", cr.Name, err)
+		}
+		if err := r.Client.List(context.TODO(), statefulSets, listOptions...); err != nil {
+			return false, fmt.Errorf("failed to list StatefulSets for %s: %w", cr.Name, err)
+		}
+	}
+	return len(deployments.Items) == 0 && len(statefulSets.Items) == 0, nil
+}
+
 func (r *ReconcileArgoCD) deleteClusterResources(cr *argoproj.ArgoCD) error {
 	selector, err := argocdInstanceSelector(cr.Name)
 	if err != nil {
@@ -848,6 +1194,100 @@ func (r *ReconcileArgoCD) deleteClusterResources(cr *argoproj.ArgoCD) error {
 	return nil
 }
 
+// orphanedClusterResourcesSweepInterval is the minimum interval between two sweeps of the cluster scoped resources
+// left behind by deleted ArgoCD instances.
+const orphanedClusterResourcesSweepInterval = 10 * time.Minute
+
+// sweepOrphanedClusterResources will delete the cluster scoped resources left behind by deleted ArgoCD instances, at
+// most once per orphanedClusterResourcesSweepInterval. The sweep is claimed before it runs, so that concurrent
+// reconciles don't sweep at the same time, and released again when it fails.
+func (r *ReconcileArgoCD) sweepOrphanedClusterResources(ctx context.Context) error {
+	r.orphanedClusterResourcesSweepMutex.Lock()
+	lastSweep := r.lastOrphanedClusterResourcesSweep
+	if time.Since(lastSweep) < orphanedClusterResourcesSweepInterval {
+		r.orphanedClusterResourcesSweepMutex.Unlock()
+		return nil
+	}
+	r.lastOrphanedClusterResourcesSweep = time.Now()
+	r.orphanedClusterResourcesSweepMutex.Unlock()
+
+	if err := r.deleteOrphanedClusterResources(ctx); err != nil {
+		r.orphanedClusterResourcesSweepMutex.Lock()
+		r.lastOrphanedClusterResourcesSweep = lastSweep
+		r.orphanedClusterResourcesSweepMutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+// deleteOrphanedClusterResources will delete the ClusterRoles and ClusterRoleBindings created for ArgoCD instances that
+// no longer exist. Cluster scoped resources can't be owned by a namespaced ArgoCD, so they are only deleted by the
+// deletion finalizer and would leak when an ArgoCD is deleted without it, e.g. after the finalizer was removed by hand.
+func (r *ReconcileArgoCD) deleteOrphanedClusterResources(ctx context.Context) error {
+	selector := client.MatchingLabels{common.ArgoCDKeyPartOf: common.ArgoCDAppName}
+
+	clusterRoleList := &v1.ClusterRoleList{}
+	if err := r.Client.List(ctx, clusterRoleList, selector); err != nil {
+		return fmt.Errorf("failed to list ClusterRoles: %w", err)
+	}
+	for i := range clusterRoleList.Items {
+		clusterRole := &clusterRoleList.Items[i]
+		orphaned, err := r.isOrphanedClusterResource(ctx, clusterRole)
+		if err != nil {
+			return err
+		}
+		if !orphaned {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting ClusterRole %s of deleted Argo CD instance %s in namespace %s", clusterRole.Name,
+			clusterRole.Annotations[common.AnnotationName], clusterRole.Annotations[common.AnnotationNamespace]))
+		if err := r.Client.Delete(ctx, clusterRole); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete orphaned ClusterRole %q: %w", clusterRole.Name, err)
+		}
+	}
+
+	clusterBindingList := &v1.ClusterRoleBindingList{}
+	if err := r.Client.List(ctx, clusterBindingList, selector); err != nil {
+		return fmt.Errorf("failed to list ClusterRoleBindings: %w", err)
+	}
+	for i := range clusterBindingList.Items {
+		clusterBinding := &clusterBindingList.Items[i]
+		orphaned, err := r.isOrphanedClusterResource(ctx, clusterBinding)
+		if err != nil {
+			return err
+		}
+		if !orphaned {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting ClusterRoleBinding %s of deleted Argo CD instance %s in namespace %s", clusterBinding.Name,
+			clusterBinding.Annotations[common.AnnotationName], clusterBinding.Annotations[common.AnnotationNamespace]))
+		if err := r.Client.Delete(ctx, clusterBinding); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete orphaned ClusterRoleBinding %q: %w", clusterBinding.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// isOrphanedClusterResource returns true if the ArgoCD the given cluster scoped resource was created for no longer
+// exists. Resources without owner annotations predate them and are never considered orphaned.
+func (r *ReconcileArgoCD) isOrphanedClusterResource(ctx context.Context, obj metav1.Object) (bool, error) {
+	name, hasName := obj.GetAnnotations()[common.AnnotationName]
+	namespace, hasNamespace := obj.GetAnnotations()[common.AnnotationNamespace]
+	if !hasName || !hasNamespace {
+		return false, nil
+	}
+
+	err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &argoproj.ArgoCD{})
+	if err == nil {
+		return false, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, fmt.Errorf("failed to get Argo CD instance %s in namespace %s: %w", name, namespace, err)
+}
+
 func (r *ReconcileArgoCD) removeManagedByLabelFromNamespaces(namespace string) error {
 	nsList := &corev1.NamespaceList{}
 	listOption := client.MatchingLabels{
@@ -893,16 +1333,20 @@ func argocdInstanceSelector(name string) (labels.Selector, error) {
 }
 
 func (r *ReconcileArgoCD) removeDeletionFinalizer(argocd *argoproj.ArgoCD) error {
+	// patch only the finalizers, so that a concurrent update, e.g. of the status, doesn't cause a conflict
+	patch := client.MergeFrom(argocd.DeepCopy())
 	argocd.Finalizers = removeString(argocd.GetFinalizers(), common.ArgoCDDeletionFinalizer)
-	if err := r.Client.Update(context.TODO(), argocd); err != nil {
+	if err := r.Client.Patch(context.TODO(), argocd, patch); err != nil {
 		return fmt.Errorf("failed to remove deletion finalizer from %s: %w", argocd.Name, err)
 	}
 	return nil
 }
 
 func (r *ReconcileArgoCD) addDeletionFinalizer(argocd *argoproj.ArgoCD) error {
+	// patch only the finalizers, so that a concurrent update, e.g. of the status, doesn't cause a conflict
+	patch := client.MergeFrom(argocd.DeepCopy())
 	argocd.Finalizers = append(argocd.Finalizers, common.ArgoCDDeletionFinalizer)
-	if err := r.Client.Update(context.TODO(), argocd); err != nil {
+	if err := r.Client.Patch(context.TODO(), argocd, patch); err != nil {
 		return fmt.Errorf("failed to add deletion finalizer for %s: %w", argocd.Name, err)
 	}
 	return nil
@@ -1027,6 +1471,9 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 	// Watch for changes to Ingress sub-resources owned by ArgoCD instances.
 	bldr.Owns(&networkingv1.Ingress{})
 
+	// Watch for changes to NetworkPolicy sub-resources owned by ArgoCD instances.
+	bldr.Owns(&networkingv1.NetworkPolicy{})
+
 	bldr.Owns(&v1.Role{})
 
 	bldr.Owns(&v1.RoleBinding{})
@@ -1116,19 +1563,36 @@ func (r *ReconcileArgoCD) triggerRollout(obj interface{}, key string) error {
 	}
 }
 
+var (
+	clusterConfigNamespacesMutex sync.Mutex
+	// cachedClusterConfigNamespacesValue is the value the cached cluster config namespaces were parsed from
+	cachedClusterConfigNamespacesValue string
+	cachedClusterConfigNamespaces      []string
+)
+
+// getClusterConfigNamespaces returns the parsed comma separated list of cluster config namespaces. The list is only
+// parsed again when the given value differs from the previous one. The returned slice must not be modified.
+func getClusterConfigNamespaces(namespaces string) []string {
+	clusterConfigNamespacesMutex.Lock()
+	defer clusterConfigNamespacesMutex.Unlock()
+	if cachedClusterConfigNamespaces == nil || cachedClusterConfigNamespacesValue != namespaces {
+		cachedClusterConfigNamespaces = splitList(namespaces)
+		cachedClusterConfigNamespacesValue = namespaces
+	}
+	return cachedClusterConfigNamespaces
+}
+
+// allowedNamespace returns true if the current namespace is in the comma separated list of namespaces. The list
+// may contain glob patterns, e.g. `argocd-*`.
 func allowedNamespace(current string, namespaces string) bool {
 
-	clusterConfigNamespaces := splitList(namespaces)
+	clusterConfigNamespaces := getClusterConfigNamespaces(namespaces)
 	if len(clusterConfigNamespaces) > 0 {
 		if clusterConfigNamespaces[0] == "*" {
 			return true
 		}
 
-		for _, n := range clusterConfigNamespaces {
-			if n == current {
-				return true
-			}
-		}
+		return glob.MatchStringInList(clusterConfigNamespaces, current, false)
 	}
 	return false
 }
@@ -1369,8 +1833,18 @@ func (r *ReconcileArgoCD) setManagedNamespaces(cr *argoproj.ArgoCD) error {
 }
 
 // getSourceNamespaces retrieves a list of namespaces that match the sourceNamespaces
-// pattern specified in the given ArgoCD
+// pattern specified in the given ArgoCD.
+// Patterns are globs matched against the whole namespace name, so "dev" matches only
+// the "dev" namespace while "dev*" also matches "development".
+// The result is served from the per reconcile cache of the ArgoCD when it has been populated.
 func (r *ReconcileArgoCD) getSourceNamespaces(cr *argoproj.ArgoCD) ([]string, error) {
+	r.sourceNamespacesMutex.Lock()
+	cached, ok := r.sourceNamespaces[types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}]
+	r.sourceNamespacesMutex.Unlock()
+	if ok {
+		return append([]string{}, cached...), nil
+	}
+
 	sourceNamespaces := []string{}
 	namespaces := &corev1.NamespaceList{}
 
@@ -1387,6 +1861,99 @@ func (r *ReconcileArgoCD) getSourceNamespaces(cr *argoproj.ArgoCD) ([]string, er
 	return sourceNamespaces, nil
 }
 
+// controlPlaneSourceNamespaceReason is the reason of the warning event emitted when the namespace of an ArgoCD is
+// listed in its own source namespaces.
+const controlPlaneSourceNamespaceReason = "ControlPlaneSourceNamespace"
+
+// validateSourceNamespaces emits a warning event when the namespace of the given ArgoCD is listed in its Apps or
+// ApplicationSet source namespaces. The instance always manages the Applications of its own namespace, so listing it
+// only results in source namespace roles and labels being added to the control plane namespace. The Apps source
+// namespaces are globs, so patterns such as "*" matching the namespace are reported as well.
+func (r *ReconcileArgoCD) validateSourceNamespaces(cr *argoproj.ArgoCD) error {
+	var fields []string
+	if glob.MatchStringInList(cr.Spec.SourceNamespaces, cr.Namespace, false) {
+		fields = append(fields, ".spec.sourceNamespaces")
+	}
+	if cr.Spec.ApplicationSet != nil && contains(cr.Spec.ApplicationSet.SourceNamespaces, cr.Namespace) {
+		fields = append(fields, ".spec.applicationSet.sourceNamespaces")
+	}
+
+	if len(fields) == 0 {
+		r.clearWarningEvent(cr, controlPlaneSourceNamespaceReason)
+		return nil
+	}
+
+	message := fmt.Sprintf("the Argo CD namespace %s should not be listed in %s, as Applications in it are always managed by the instance",
+		cr.Namespace, strings.Join(fields, " and "))
+	return r.emitWarningEvent(cr, controlPlaneSourceNamespaceReason, message)
+}
+
+// invalidLogSettingsReason is the reason of the warning event emitted when a log level or format of an ArgoCD is not
+// recognized.
+const invalidLogSettingsReason = "InvalidLogSettings"
+
+// validateLogSettings emits a warning event listing the log levels and formats of the given ArgoCD that are not
+// recognized, as the components silently fall back to the default log level or format for them.
+func (r *ReconcileArgoCD) validateLogSettings(cr *argoproj.ArgoCD) error {
+	var invalid []string
+	checkLevel := func(field, value string) {
+		if value != "" && getLogLevel(value) != value {
+			invalid = append(invalid, fmt.Sprintf("%s %q (using %q)", field, value, common.ArgoCDDefaultLogLevel))
+		}
+	}
+	checkFormat := func(field, value string) {
+		if value != "" && getLogFormat(value) != value {
+			invalid = append(invalid, fmt.Sprintf("%s %q (using %q)", field, value, common.ArgoCDDefaultLogFormat))
+		}
+	}
+
+	checkLevel(".spec.controller.logLevel", cr.Spec.Controller.LogLevel)
+	checkFormat(".spec.controller.logFormat", cr.Spec.Controller.LogFormat)
+	checkLevel(".spec.repo.logLevel", cr.Spec.Repo.LogLevel)
+	checkFormat(".spec.repo.logFormat", cr.Spec.Repo.LogFormat)
+	checkLevel(".spec.server.logLevel", cr.Spec.Server.LogLevel)
+	checkFormat(".spec.server.logFormat", cr.Spec.Server.LogFormat)
+	checkLevel(".spec.notifications.logLevel", cr.Spec.Notifications.LogLevel)
+	checkFormat(".spec.notifications.logFormat", cr.Spec.Notifications.LogFormat)
+	if cr.Spec.ApplicationSet != nil {
+		checkLevel(".spec.applicationSet.logLevel", cr.Spec.ApplicationSet.LogLevel)
+	}
+
+	if len(invalid) == 0 {
+		r.clearWarningEvent(cr, invalidLogSettingsReason)
+		return nil
+	}
+
+	message := fmt.Sprintf("unrecognized log settings are replaced by the defaults: %s", strings.Join(invalid, ", "))
+	return r.emitWarningEvent(cr, invalidLogSettingsReason, message)
+}
+
+// cacheSourceNamespaces lists the namespaces matching the sourceNamespaces of the given ArgoCD once,
+// so that subsequent calls to getSourceNamespaces within the same reconcile don't list all namespaces again.
+func (r *ReconcileArgoCD) cacheSourceNamespaces(cr *argoproj.ArgoCD) error {
+	key := types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}
+	r.invalidateSourceNamespaces(key)
+	sourceNamespaces, err := r.getSourceNamespaces(cr)
+	if err != nil {
+		return err
+	}
+
+	r.sourceNamespacesMutex.Lock()
+	defer r.sourceNamespacesMutex.Unlock()
+	if r.sourceNamespaces == nil {
+		r.sourceNamespaces = make(map[types.NamespacedName][]string)
+	}
+	r.sourceNamespaces[key] = sourceNamespaces
+	return nil
+}
+
+// invalidateSourceNamespaces clears the source namespaces of the given ArgoCD cached by cacheSourceNamespaces.
+func (r *ReconcileArgoCD) invalidateSourceNamespaces(key types.NamespacedName) {
+	r.sourceNamespacesMutex.Lock()
+	defer r.sourceNamespacesMutex.Unlock()
+	delete(r.sourceNamespaces, key)
+}
+
 func (r *ReconcileArgoCD) setManagedSourceNamespaces(cr *argoproj.ArgoCD) error {
 	r.ManagedSourceNamespaces = make(map[string]string)
 	namespaces := &corev1.NamespaceList{}
@@ -1436,9 +2003,32 @@ func (r *ReconcileArgoCD) removeUnmanagedSourceNamespaceResources(cr *argoproj.A
 	return nil
 }
 
+// namespaceGetBackoff is the backoff of the retries of the namespace Gets failing with a transient error.
+var namespaceGetBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 50 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// isTransientAPIError returns true if the given error is a transient failure of the API server, which may succeed
+// when retried.
+func isTransientAPIError(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err)
+}
+
+// getNamespace retrieves the namespace with the given name, retrying with an exponential backoff on transient
+// errors, so that an API hiccup doesn't fail the reconcile of the source namespaces.
+func (r *ReconcileArgoCD) getNamespace(ctx context.Context, name string, namespace *corev1.Namespace) error {
+	return retry.OnError(namespaceGetBackoff, isTransientAPIError, func() error {
+		return r.Client.Get(ctx, types.NamespacedName{Name: name}, namespace)
+	})
+}
+
 func (r *ReconcileArgoCD) cleanupUnmanagedSourceNamespaceResources(cr *argoproj.ArgoCD, ns string) error {
 	namespace := corev1.Namespace{}
-	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ns}, &namespace); err != nil {
+	if err := r.getNamespace(context.TODO(), ns, &namespace); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return err
 		}
@@ -1540,13 +2130,26 @@ func getOpenShiftAPIURL() string {
 	return out
 }
 
+// AddSeccompProfileForOpenShift sets the RuntimeDefault seccomp profile on the pod spec when running on
+// OpenShift 4.11 or later. If the cluster version can't be determined, e.g. because reading the ClusterVersion
+// is not permitted, the profile is set as well since all supported OpenShift versions accept it.
 func AddSeccompProfileForOpenShift(client client.Client, podspec *corev1.PodSpec) {
+	AddSeccompProfileForOpenShiftWithContext(context.TODO(), client, podspec)
+}
+
+// AddSeccompProfileForOpenShiftWithContext is AddSeccompProfileForOpenShift bound to the given context, so that
+// reading the cluster version is cancelled with it.
+func AddSeccompProfileForOpenShiftWithContext(ctx context.Context, client client.Client, podspec *corev1.PodSpec) {
 	if !IsVersionAPIAvailable() {
 		return
 	}
-	version, err := getClusterVersion(client)
+	version, err := getClusterVersion(ctx, client)
 	if err != nil {
-		log.Error(err, "couldn't get OpenShift version")
+		if apierrors.IsForbidden(err) {
+			log.Info("not permitted to read the OpenShift cluster version, assuming OpenShift 4.11 or later")
+		} else {
+			log.Error(err, "couldn't get OpenShift version")
+		}
 	}
 	if version == "" || semver.Compare(fmt.Sprintf("v%s", version), "v4.10.999") > 0 {
 		if podspec.SecurityContext == nil {
@@ -1561,20 +2164,38 @@ func AddSeccompProfileForOpenShift(client client.Client, podspec *corev1.PodSpec
 	}
 }
 
-// getClusterVersion returns the OpenShift Cluster version in which the operator is installed
-func getClusterVersion(client client.Client) (string, error) {
+// getClusterVersion returns the OpenShift Cluster version in which the operator is installed. The version is cached
+// for clusterVersionCacheTTL. When the operator is not permitted to read the ClusterVersion, an empty version is
+// cached, which is treated as OpenShift 4.11 or later, and the error is only returned by the read that caches it.
+// Other errors are not cached.
+func getClusterVersion(ctx context.Context, client client.Client) (string, error) {
 	if !IsVersionAPIAvailable() {
 		return "", nil
 	}
+
+	clusterVersionCache.Lock()
+	defer clusterVersionCache.Unlock()
+	if time.Now().Before(clusterVersionCache.expiry) {
+		return clusterVersionCache.version, nil
+	}
+
+	version := ""
 	clusterVersion := &configv1.ClusterVersion{}
-	err := client.Get(context.TODO(), types.NamespacedName{Name: "version"}, clusterVersion)
+	err := client.Get(ctx, types.NamespacedName{Name: "version"}, clusterVersion)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			return "", nil
+		if !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+			return "", err
 		}
-		return "", err
+	} else {
+		version = clusterVersion.Status.Desired.Version
+	}
+
+	clusterVersionCache.version = version
+	clusterVersionCache.expiry = time.Now().Add(clusterVersionCacheTTL)
+	if apierrors.IsForbidden(err) {
+		return version, err
 	}
-	return clusterVersion.Status.Desired.Version, nil
+	return version, nil
 }
 
 // generateRandomBytes returns a securely generated random bytes.
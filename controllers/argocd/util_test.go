@@ -3,6 +3,7 @@ package argocd
 import (
 	"context"
 	b64 "encoding/base64"
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
@@ -10,15 +11,21 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 
+	configv1 "github.com/openshift/api/config/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	testclient "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -28,6 +35,7 @@ const (
 	redisTestImage        = "testing/redis:latest"
 	redisHATestImage      = "testing/redis:latest-ha"
 	redisHAProxyTestImage = "testing/redis-ha-haproxy:latest-ha"
+	redisTestDigest       = "sha256:1f3e9a7c2b5d8e4f6a0c9b7d3e5f1a2b4c6d8e0f2a4b6c8d0e2f4a6b8c0d2e4f"
 )
 
 func parallelismLimit(n int32) argoCDOpt {
@@ -131,6 +139,29 @@ var imageTests = []struct {
 			t.Setenv(common.ArgoCDRedisImageEnvName, redisTestImage)
 		},
 	},
+	{
+		name:      "redis default digest configuration",
+		imageFunc: getRedisContainerImage,
+		want:      common.ArgoCDDefaultRedisImage + "@" + common.ArgoCDDefaultRedisVersion,
+	},
+	{
+		name:      "redis spec digest configuration",
+		imageFunc: getRedisContainerImage,
+		want:      "testing/redis@" + redisTestDigest,
+		opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+			a.Spec.Redis.Image = "testing/redis"
+			a.Spec.Redis.Version = redisTestDigest
+		}},
+	},
+	{
+		name:      "redis spec tag configuration",
+		imageFunc: getRedisContainerImage,
+		want:      "testing/redis:7.0.11-alpine",
+		opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+			a.Spec.Redis.Image = "testing/redis"
+			a.Spec.Redis.Version = "7.0.11-alpine"
+		}},
+	},
 	{
 		name:      "redis ha default configuration",
 		imageFunc: getRedisHAContainerImage,
@@ -196,6 +227,119 @@ func TestContainerImages_configuration(t *testing.T) {
 	}
 }
 
+func TestResolveImage(t *testing.T) {
+	const envName = "TEST_RESOLVE_IMAGE"
+
+	tests := []struct {
+		name             string
+		env              string
+		registry         string
+		componentImage   string
+		componentVersion string
+		crImage          string
+		crVersion        string
+		defaultImage     string
+		want             string
+	}{
+		{
+			name: "default image and version",
+			want: "default/image:v1",
+		},
+		{
+			name:             "component image and version override",
+			componentImage:   "component/image",
+			componentVersion: "v2",
+			crImage:          "cr/image",
+			crVersion:        "v3",
+			want:             "component/image:v2",
+		},
+		{
+			name:      "fallback to cr image and version",
+			crImage:   "cr/image",
+			crVersion: "v3",
+			want:      "cr/image:v3",
+		},
+		{
+			name:             "component version with cr image",
+			componentVersion: "v2",
+			crImage:          "cr/image",
+			want:             "cr/image:v2",
+		},
+		{
+			name: "env override when defaults are used",
+			env:  "env/image:v4",
+			want: "env/image:v4",
+		},
+		{
+			name:           "env ignored when component image is set",
+			env:            "env/image:v4",
+			componentImage: "component/image",
+			want:           "component/image:v1",
+		},
+		{
+			name:      "env ignored when cr version is set",
+			env:       "env/image:v4",
+			crVersion: "v3",
+			want:      "default/image:v3",
+		},
+		{
+			name:     "registry prefixed to default image",
+			registry: "mirror.example.com/",
+			want:     "mirror.example.com/default/image:v1",
+		},
+		{
+			name:         "registry replaced in default image",
+			registry:     "mirror.example.com",
+			defaultImage: "quay.io/default/image",
+			want:         "mirror.example.com/default/image:v1",
+		},
+		{
+			name:         "registry with port replaced in default image",
+			registry:     "mirror.example.com",
+			defaultImage: "localhost:5000/default/image",
+			want:         "mirror.example.com/default/image:v1",
+		},
+		{
+			name:         "registry prefixed to default image without registry",
+			registry:     "mirror.example.com",
+			defaultImage: "redis",
+			want:         "mirror.example.com/redis:v1",
+		},
+		{
+			name:      "registry prefixed to default image with cr version",
+			registry:  "mirror.example.com",
+			crVersion: "v3",
+			want:      "mirror.example.com/default/image:v3",
+		},
+		{
+			name:           "registry ignored when component image is set",
+			registry:       "mirror.example.com",
+			componentImage: "quay.io/component/image",
+			want:           "quay.io/component/image:v1",
+		},
+		{
+			name:     "registry ignored when env override is used",
+			registry: "mirror.example.com",
+			env:      "env/image:v4",
+			want:     "env/image:v4",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(envName, test.env)
+			t.Setenv(common.ArgoCDImageRegistryEnvName, test.registry)
+			defaultImage := test.defaultImage
+			if defaultImage == "" {
+				defaultImage = "default/image"
+			}
+			image := resolveImage(test.componentImage, test.componentVersion, test.crImage, test.crVersion,
+				defaultImage, "v1", envName)
+			assert.Equal(t, test.want, image)
+		})
+	}
+}
+
 var argoServerURITests = []struct {
 	name         string
 	routeEnabled bool
@@ -225,6 +369,75 @@ func setRouteAPIFound(t *testing.T, routeEnabled bool) {
 	routeAPIFound = routeEnabled
 }
 
+func TestGetComponentResources_defaults(t *testing.T) {
+	redisDefaults := v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(common.ArgoCDDefaultRedisResourceLimitCPU),
+			v1.ResourceMemory: resource.MustParse(common.ArgoCDDefaultRedisResourceLimitMemory),
+		},
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(common.ArgoCDDefaultRedisResourceRequestCPU),
+			v1.ResourceMemory: resource.MustParse(common.ArgoCDDefaultRedisResourceRequestMemory),
+		},
+	}
+	userResources := v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+
+	t.Run("defaults apply when unset", func(t *testing.T) {
+		cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+		})
+		assert.Equal(t, redisDefaults, getRedisResources(cr))
+		assert.Equal(t, redisDefaults, getRedisHAResources(cr))
+		appSetResources := getApplicationSetResources(cr)
+		assert.Equal(t, resource.MustParse(common.ArgoCDDefaultApplicationSetResourceRequestCPU), appSetResources.Requests[v1.ResourceCPU])
+		assert.Equal(t, resource.MustParse(common.ArgoCDDefaultApplicationSetResourceLimitMemory), appSetResources.Limits[v1.ResourceMemory])
+	})
+
+	t.Run("user values win when set", func(t *testing.T) {
+		cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{Resources: &userResources}
+			a.Spec.Redis.Resources = &userResources
+			a.Spec.HA.Resources = &userResources
+		})
+		assert.Equal(t, userResources, getRedisResources(cr))
+		assert.Equal(t, userResources, getRedisHAResources(cr))
+		assert.Equal(t, userResources, getApplicationSetResources(cr))
+	})
+
+	t.Run("defaults overridden by environment", func(t *testing.T) {
+		t.Setenv(common.ArgoCDRedisDefaultResourcesEnvName, `{"requests":{"memory":"1Gi"}}`)
+		t.Setenv(common.ArgoCDRedisHADefaultResourcesEnvName, `{}`)
+		t.Setenv(common.ArgoCDAppSetDefaultResourcesEnvName, `not-json`)
+		cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+			a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+		})
+		assert.Equal(t, userResources, getRedisResources(cr))
+		assert.Equal(t, v1.ResourceRequirements{}, getRedisHAResources(cr))
+		// invalid values fall back to the defaults
+		assert.Equal(t, resource.MustParse(common.ArgoCDDefaultApplicationSetResourceRequestCPU), getApplicationSetResources(cr).Requests[v1.ResourceCPU])
+	})
+}
+
+func TestContainerImages_registry(t *testing.T) {
+	t.Setenv(common.ArgoCDImageRegistryEnvName, "mirror.example.com")
+
+	cr := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	})
+	assert.Equal(t, argoutil.CombineImageTag("mirror.example.com/redis", common.ArgoCDDefaultRedisVersion), getRedisContainerImage(cr))
+	assert.Equal(t, argoutil.CombineImageTag("mirror.example.com/argoproj/argocd", common.ArgoCDDefaultArgoVersion), getApplicationSetContainerImage(cr))
+	assert.Equal(t, argoutil.CombineImageTag("mirror.example.com/dexidp/dex", common.ArgoCDDefaultDexVersion), getDexContainerImage(cr))
+
+	cr.Spec.Redis.Image = "registry.example.com/redis"
+	cr.Spec.ApplicationSet.Image = "registry.example.com/argocd"
+	assert.Equal(t, argoutil.CombineImageTag("registry.example.com/redis", common.ArgoCDDefaultRedisVersion), getRedisContainerImage(cr))
+	assert.Equal(t, argoutil.CombineImageTag("registry.example.com/argocd", common.ArgoCDDefaultArgoVersion), getApplicationSetContainerImage(cr))
+}
+
 func TestGetArgoServerURI(t *testing.T) {
 	for _, tt := range argoServerURITests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -303,6 +516,49 @@ func TestAddDeletionFinalizer(t *testing.T) {
 	})
 }
 
+func TestDeletionFinalizer_concurrentStatusUpdate(t *testing.T) {
+	a := makeTestArgoCD()
+
+	patches := 0
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(a).
+		WithStatusSubresource(a).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, client client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if _, ok := obj.(*argoproj.ArgoCD); ok {
+					patches++
+				}
+				return client.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	r := makeTestReconciler(cl, sch)
+
+	stored := &argoproj.ArgoCD{}
+	updateStatus := func(phase string) {
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, stored))
+		stored.Status.Phase = phase
+		assert.NoError(t, r.Client.Status().Update(context.TODO(), stored))
+	}
+
+	// the status is updated after a was read, so a is stale
+	updateStatus("Pending")
+	assert.NoError(t, r.addDeletionFinalizer(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, stored))
+	assert.True(t, stored.IsDeletionFinalizerPresent())
+	assert.Equal(t, "Pending", stored.Status.Phase)
+
+	updateStatus("Available")
+	assert.NoError(t, r.removeDeletionFinalizer(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, stored))
+	assert.False(t, stored.IsDeletionFinalizerPresent())
+	assert.Equal(t, "Available", stored.Status.Phase)
+
+	assert.Equal(t, 2, patches)
+}
+
 func TestArgoCDInstanceSelector(t *testing.T) {
 	t.Run("Selector for a Valid name", func(t *testing.T) {
 		validName := "argocd-server"
@@ -885,6 +1141,41 @@ func TestGetSourceNamespacesWithSpecificNamespace(t *testing.T) {
 	assert.NotContains(t, sourceNamespaces, "other-namespace")
 }
 
+func TestGetSourceNamespaces_cachedPerInstance(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SourceNamespaces = []string{"foo"}
+	})
+	b := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Namespace = "argocd-b"
+		cr.Spec.SourceNamespaces = []string{"bar"}
+	})
+	ns1 := v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	ns2 := v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bar"}}
+
+	resObjs := []client.Object{a, b, &ns1, &ns2}
+	subresObjs := []client.Object{a, b}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.cacheSourceNamespaces(a))
+	assert.NoError(t, r.cacheSourceNamespaces(b))
+
+	// the cache of an instance is not served to another one
+	sourceNamespaces, err := r.getSourceNamespaces(a)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo"}, sourceNamespaces)
+	sourceNamespaces, err = r.getSourceNamespaces(b)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bar"}, sourceNamespaces)
+
+	// invalidating the cache of an instance keeps the cache of the others
+	r.invalidateSourceNamespaces(types.NamespacedName{Name: a.Name, Namespace: a.Namespace})
+	assert.NotContains(t, r.sourceNamespaces, types.NamespacedName{Name: a.Name, Namespace: a.Namespace})
+	assert.Contains(t, r.sourceNamespaces, types.NamespacedName{Name: b.Name, Namespace: b.Namespace})
+}
+
 func TestGetSourceNamespacesWithMultipleSourceNamespaces(t *testing.T) {
 	a := makeTestArgoCD()
 	a.Spec = argoproj.ArgoCDSpec{
@@ -968,6 +1259,113 @@ func TestGetSourceNamespacesWithWildCardNamespace(t *testing.T) {
 	assert.Contains(t, sourceNamespaces, "test-namespace-2")
 }
 
+func TestValidateSourceNamespaces(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.SourceNamespaces = []string{"foo", a.Namespace}
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		SourceNamespaces: []string{a.Namespace},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	listWarnings := func() []v1.Event {
+		events := &v1.EventList{}
+		assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+		return events.Items
+	}
+
+	assert.NoError(t, r.validateSourceNamespaces(a))
+	events := listWarnings()
+	assert.Len(t, events, 1)
+	assert.Equal(t, v1.EventTypeWarning, events[0].Type)
+	assert.Equal(t, "ControlPlaneSourceNamespace", events[0].Reason)
+	assert.Contains(t, events[0].Message, ".spec.sourceNamespaces and .spec.applicationSet.sourceNamespaces")
+
+	// removing the control plane namespace clears the warning
+	a.Spec.SourceNamespaces = []string{"foo"}
+	a.Spec.ApplicationSet.SourceNamespaces = []string{"foo"}
+	assert.NoError(t, r.validateSourceNamespaces(a))
+	assert.Len(t, listWarnings(), 1)
+	assert.Empty(t, r.warningEvents[types.NamespacedName{Name: a.Name, Namespace: a.Namespace}])
+
+	// patterns matching the control plane namespace are reported
+	for _, pattern := range []string{"*", a.Namespace[:3] + "*"} {
+		a.Spec.SourceNamespaces = []string{"foo", pattern}
+		assert.NoError(t, r.validateSourceNamespaces(a))
+		message := r.warningEvents[types.NamespacedName{Name: a.Name, Namespace: a.Namespace}]["ControlPlaneSourceNamespace"]
+		assert.Contains(t, message, "should not be listed in .spec.sourceNamespaces,", pattern)
+		a.Spec.SourceNamespaces = []string{"foo"}
+		assert.NoError(t, r.validateSourceNamespaces(a))
+	}
+}
+
+func TestValidateLogSettings(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.Server.LogLevel = "debug"
+	a.Spec.Repo.LogFormat = "json"
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		LogLevel: "warn",
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	listWarnings := func() []v1.Event {
+		events := &v1.EventList{}
+		assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+		return events.Items
+	}
+
+	// valid log settings don't record an event
+	assert.NoError(t, r.validateLogSettings(a))
+	assert.Empty(t, listWarnings())
+
+	a.Spec.ApplicationSet.LogLevel = "verbose"
+	a.Spec.Repo.LogFormat = "yaml"
+	assert.NoError(t, r.validateLogSettings(a))
+	events := listWarnings()
+	assert.Len(t, events, 1)
+	assert.Equal(t, v1.EventTypeWarning, events[0].Type)
+	assert.Equal(t, "InvalidLogSettings", events[0].Reason)
+	assert.Contains(t, events[0].Message, `.spec.applicationSet.logLevel "verbose" (using "info")`)
+	assert.Contains(t, events[0].Message, `.spec.repo.logFormat "yaml" (using "text")`)
+
+	// fixing the log settings clears the warning
+	a.Spec.ApplicationSet.LogLevel = "error"
+	a.Spec.Repo.LogFormat = ""
+	assert.NoError(t, r.validateLogSettings(a))
+	assert.Len(t, listWarnings(), 1)
+	assert.Empty(t, r.warningEvents[types.NamespacedName{Name: a.Name, Namespace: a.Namespace}])
+}
+
+func TestReconcileComponent(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	assert.NoError(t, reconcileComponent("test", func() error { return nil }))
+
+	reconcileErr := errors.New("reconcile failed")
+	assert.Equal(t, reconcileErr, reconcileComponent("test", func() error { return reconcileErr }))
+
+	// a panic is converted into an error
+	err := reconcileComponent("test", func() error {
+		var cr *argoproj.ArgoCD
+		return errors.New(cr.Name)
+	})
+	assert.ErrorContains(t, err, "panic while reconciling test")
+	assert.ErrorContains(t, err, "nil pointer dereference")
+}
+
 func TestGenerateRandomString(t *testing.T) {
 
 	// verify the creation of unique strings
@@ -1028,3 +1426,327 @@ func TestReconcileArgoCD_reconcileDexOAuthClientSecret(t *testing.T) {
 	}
 	assert.True(t, tokenExists, "Dex is enabled but unable to create oauth client secret")
 }
+
+func TestReconcileArgoCD_reconcileDexOAuthClientSecret_regenerate(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+		ac.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	_, err := r.reconcileServiceAccount(common.ArgoCDDefaultDexServiceAccountName, a)
+	assert.NoError(t, err)
+
+	tokenSecrets := func() []v1.ObjectReference {
+		sa := newServiceAccountWithName(common.ArgoCDDefaultDexServiceAccountName, a)
+		assert.NoError(t, argoutil.FetchObject(r.Client, a.Namespace, sa.Name, sa))
+		return sa.Secrets
+	}
+
+	// the token secret is only created once
+	_, err = r.getDexOAuthClientSecret(a)
+	assert.NoError(t, err)
+	_, err = r.getDexOAuthClientSecret(a)
+	assert.NoError(t, err)
+
+	refs := tokenSecrets()
+	assert.Len(t, refs, 1)
+	secrets := &v1.SecretList{}
+	assert.NoError(t, r.Client.List(context.TODO(), secrets, client.InNamespace(a.Namespace)))
+	assert.Len(t, secrets.Items, 1)
+	assert.Equal(t, refs[0].Name, secrets.Items[0].Name)
+	assert.Len(t, secrets.Items[0].OwnerReferences, 1)
+
+	// a deleted token secret is replaced and the stale reference dropped
+	assert.NoError(t, r.Client.Delete(context.TODO(), &secrets.Items[0]))
+	_, err = r.getDexOAuthClientSecret(a)
+	assert.NoError(t, err)
+
+	newRefs := tokenSecrets()
+	assert.Len(t, newRefs, 1)
+	assert.NotEqual(t, refs[0].Name, newRefs[0].Name)
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, newRefs[0].Name, &v1.Secret{}))
+}
+
+func setVersionAPIFound(t *testing.T, found bool) {
+	versionAPIFoundTemp := versionAPIFound
+	t.Cleanup(func() {
+		versionAPIFound = versionAPIFoundTemp
+		resetClusterVersionCache()
+	})
+	versionAPIFound = found
+	resetClusterVersionCache()
+}
+
+func TestAddSeccompProfileForOpenShift(t *testing.T) {
+	setVersionAPIFound(t, true)
+	sch := makeTestReconcilerScheme(configv1.AddToScheme)
+
+	t.Run("cluster version read is forbidden", func(t *testing.T) {
+		resetClusterVersionCache()
+		gets := 0
+		cl := fake.NewClientBuilder().
+			WithScheme(sch).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					gets++
+					return apierrors.NewForbidden(configv1.Resource("clusterversions"), key.Name, errors.New("forbidden"))
+				},
+			}).
+			Build()
+
+		// OpenShift 4.11 or later is assumed and the outcome is cached
+		for i := 0; i < 3; i++ {
+			podSpec := &v1.PodSpec{}
+			assert.NotPanics(t, func() { AddSeccompProfileForOpenShift(cl, podSpec) })
+			assert.Equal(t, v1.SeccompProfileTypeRuntimeDefault, podSpec.SecurityContext.SeccompProfile.Type)
+		}
+		assert.Equal(t, 1, gets)
+	})
+
+	t.Run("other errors are not cached", func(t *testing.T) {
+		resetClusterVersionCache()
+		gets := 0
+		cl := fake.NewClientBuilder().
+			WithScheme(sch).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					gets++
+					return errors.New("unavailable")
+				},
+			}).
+			Build()
+
+		for i := 0; i < 2; i++ {
+			_, err := getClusterVersion(context.TODO(), cl)
+			assert.Error(t, err)
+		}
+		assert.Equal(t, 2, gets)
+	})
+
+	t.Run("cluster version is cached", func(t *testing.T) {
+		resetClusterVersionCache()
+		gets := 0
+		cl := fake.NewClientBuilder().
+			WithScheme(sch).
+			WithObjects(&configv1.ClusterVersion{
+				ObjectMeta: metav1.ObjectMeta{Name: "version"},
+				Status:     configv1.ClusterVersionStatus{Desired: configv1.Update{Version: "4.10.3"}},
+			}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					gets++
+					return client.Get(ctx, key, obj, opts...)
+				},
+			}).
+			Build()
+
+		for i := 0; i < 3; i++ {
+			podSpec := &v1.PodSpec{}
+			AddSeccompProfileForOpenShift(cl, podSpec)
+			// OpenShift 4.10 doesn't support the seccomp profile
+			assert.Nil(t, podSpec.SecurityContext)
+		}
+		assert.Equal(t, 1, gets)
+	})
+	t.Run("cluster version is read again after a reset", func(t *testing.T) {
+		newClient := func(version string) client.Client {
+			return fake.NewClientBuilder().
+				WithScheme(sch).
+				WithObjects(&configv1.ClusterVersion{
+					ObjectMeta: metav1.ObjectMeta{Name: "version"},
+					Status:     configv1.ClusterVersionStatus{Desired: configv1.Update{Version: version}},
+				}).
+				Build()
+		}
+
+		resetClusterVersionCache()
+		version, err := getClusterVersion(context.TODO(), newClient("4.10.3"))
+		assert.NoError(t, err)
+		assert.Equal(t, "4.10.3", version)
+
+		resetClusterVersionCache()
+		version, err = getClusterVersion(context.TODO(), newClient("4.14.1"))
+		assert.NoError(t, err)
+		assert.Equal(t, "4.14.1", version)
+	})
+
+}
+
+func TestAllowedNamespace(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces string
+		current    string
+		want       bool
+	}{
+		{name: "empty list", namespaces: "", current: "argocd", want: false},
+		{name: "all namespaces", namespaces: "*", current: "argocd", want: true},
+		{name: "exact name", namespaces: "foo, argocd", current: "argocd", want: true},
+		{name: "exact name not listed", namespaces: "foo,bar", current: "argocd", want: false},
+		{name: "glob pattern matching", namespaces: "foo,argocd-*", current: "argocd-team-a", want: true},
+		{name: "glob pattern not matching", namespaces: "foo,argocd-*", current: "team-a-argocd", want: false},
+		{name: "glob pattern not matching the prefix alone", namespaces: "argocd-?", current: "argocd-", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, allowedNamespace(test.current, test.namespaces))
+		})
+	}
+}
+
+func TestGetClusterConfigNamespaces(t *testing.T) {
+	namespaces := getClusterConfigNamespaces("foo, bar")
+	assert.Equal(t, []string{"foo", "bar"}, namespaces)
+
+	// the same value returns the cached list
+	cached := getClusterConfigNamespaces("foo, bar")
+	assert.Equal(t, []string{"foo", "bar"}, cached)
+	assert.Same(t, &namespaces[0], &cached[0])
+	assert.True(t, allowedNamespace("bar", "foo, bar"))
+	assert.False(t, allowedNamespace("baz", "foo, bar"))
+
+	// a changed value is parsed again
+	assert.Equal(t, []string{"baz"}, getClusterConfigNamespaces("baz"))
+	assert.True(t, allowedNamespace("baz", "baz"))
+	assert.False(t, allowedNamespace("foo", "baz"))
+}
+
+func TestValidateRemoteRedis(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	tests := []struct {
+		name          string
+		remote        string
+		wantRemote    bool
+		wantLocal     bool
+		wantAddress   string
+		wantWarnEvent bool
+	}{
+		{
+			name:        "valid remote",
+			remote:      "redis.example.com:6379",
+			wantRemote:  true,
+			wantAddress: "redis.example.com:6379",
+		},
+		{
+			name:          "remote without port",
+			remote:        "redis.example.com",
+			wantLocal:     true,
+			wantAddress:   "argocd-redis.argocd.svc.cluster.local:6379",
+			wantWarnEvent: true,
+		},
+		{
+			name:          "remote with invalid port",
+			remote:        "redis.example.com:redis",
+			wantLocal:     true,
+			wantAddress:   "argocd-redis.argocd.svc.cluster.local:6379",
+			wantWarnEvent: true,
+		},
+		{
+			name:        "empty remote",
+			remote:      "",
+			wantLocal:   true,
+			wantAddress: "argocd-redis.argocd.svc.cluster.local:6379",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			remote := test.remote
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.Redis.Remote = &remote
+			})
+
+			resObjs := []client.Object{a}
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			assert.NoError(t, r.validateRemoteRedis(a))
+			assert.Equal(t, test.wantRemote, isRemoteRedis(a))
+			assert.Equal(t, test.wantLocal, isLocalRedisEnabled(a))
+			assert.Equal(t, test.wantAddress, getRedisServerAddress(a))
+
+			events := &v1.EventList{}
+			assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(a.Namespace)))
+			if test.wantWarnEvent {
+				assert.Len(t, events.Items, 1)
+				assert.Equal(t, "InvalidRemoteRedis", events.Items[0].Reason)
+			} else {
+				assert.Empty(t, events.Items)
+			}
+		})
+	}
+}
+
+func TestGetRedisServerAddress(t *testing.T) {
+	remote := "redis.example.com:6379"
+
+	tests := []struct {
+		name string
+		opts []argoCDOpt
+		want string
+	}{
+		{
+			name: "standalone redis",
+			want: "argocd-redis.argocd.svc.cluster.local:6379",
+		},
+		{
+			name: "redis ha",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.HA.Enabled = true
+			}},
+			want: "argocd-redis-ha-haproxy.argocd.svc.cluster.local:6379",
+		},
+		{
+			name: "remote redis",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.Redis.Remote = &remote
+			}},
+			want: remote,
+		},
+		{
+			name: "remote redis takes precedence over redis ha",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.HA.Enabled = true
+				a.Spec.Redis.Remote = &remote
+			}},
+			want: remote,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, getRedisServerAddress(makeTestArgoCD(test.opts...)))
+		})
+	}
+}
+
+func TestFqdnServiceRef_ClusterDomain(t *testing.T) {
+	a := makeTestArgoCD()
+
+	assert.Equal(t, "argocd-repo-server.argocd.svc.cluster.local:8081", fqdnServiceRef("repo-server", 8081, a))
+
+	t.Setenv("CLUSTER_DOMAIN", "example.internal.")
+	assert.Equal(t, "argocd-repo-server.argocd.svc.example.internal:8081", fqdnServiceRef("repo-server", 8081, a))
+	assert.Equal(t, "argocd-redis.argocd.svc.example.internal:6379", getRedisServerAddress(a))
+
+	a.Spec.HA.Enabled = true
+	assert.Equal(t, "argocd-redis-ha-haproxy.argocd.svc.example.internal:6379", getRedisServerAddress(a))
+}
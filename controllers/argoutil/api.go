@@ -50,3 +50,45 @@ func VerifyAPI(group string, version string) (bool, error) {
 	log.Info(fmt.Sprintf("%s/%s API verified", group, version))
 	return true, nil
 }
+
+// VerifyAPIResources will verify that the given resources of the given group/version are present in the cluster.
+func VerifyAPIResources(group string, version string, resources ...string) (bool, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Error(err, "unable to get k8s config")
+		return false, err
+	}
+
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		log.Error(err, "unable to create k8s client")
+		return false, err
+	}
+
+	gv := schema.GroupVersion{
+		Group:   group,
+		Version: version,
+	}
+
+	resourceList, err := k8s.Discovery().ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		// error, API not available
+		return false, nil
+	}
+
+	for _, resource := range resources {
+		found := false
+		for _, r := range resourceList.APIResources {
+			if r.Name == resource {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+
+	log.Info(fmt.Sprintf("%s/%s %v API verified", group, version, resources))
+	return true, nil
+}
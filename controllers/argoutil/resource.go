@@ -76,7 +76,12 @@ func CreateEvent(client client.Client, eventType, action, message, reason string
 // FetchObject will retrieve the object with the given namespace and name using the Kubernetes API.
 // The result will be stored in the given object.
 func FetchObject(client client.Client, namespace string, name string, obj client.Object) error {
-	return client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj)
+	return FetchObjectWithContext(context.TODO(), client, namespace, name, obj)
+}
+
+// FetchObjectWithContext is FetchObject bound to the given context, so that the request is cancelled with it.
+func FetchObjectWithContext(ctx context.Context, client client.Client, namespace string, name string, obj client.Object) error {
+	return client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj)
 }
 
 // FetchStorageSecretName will return the name of the Secret to use for the export process.
@@ -89,11 +94,29 @@ func FetchStorageSecretName(export *argoprojv1alpha1.ArgoCDExport) string {
 }
 
 // IsObjectFound will perform a basic check that the given object exists via the Kubernetes API.
-// If an error occurs as part of the check, the function will return false.
+// Only a NotFound error is reported as the object not existing, any other error is ignored and the function
+// returns true. Use ObjectExists to handle these errors.
 func IsObjectFound(client client.Client, namespace string, name string, obj client.Object) bool {
 	return !apierrors.IsNotFound(FetchObject(client, namespace, name, obj))
 }
 
+// ObjectExists will retrieve the object with the given namespace and name using the Kubernetes API, storing the
+// result in the given object. It returns false if the object doesn't exist, and the error of any other failure.
+func ObjectExists(client client.Client, namespace string, name string, obj client.Object) (bool, error) {
+	return ObjectExistsWithContext(context.TODO(), client, namespace, name, obj)
+}
+
+// ObjectExistsWithContext is ObjectExists bound to the given context, so that the request is cancelled with it.
+func ObjectExistsWithContext(ctx context.Context, client client.Client, namespace string, name string, obj client.Object) (bool, error) {
+	if err := FetchObjectWithContext(ctx, client, namespace, name, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // NameWithSuffix will return a string using the Name from the given ObjectMeta with the provded suffix appended.
 // Example: If ObjectMeta.Name is "test" and suffix is "object", the value of "test-object" will be returned.
 func NameWithSuffix(meta metav1.ObjectMeta, suffix string) string {
@@ -15,10 +15,18 @@
 package argoutil
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -57,3 +65,68 @@ func TestDefaultAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestCombineImageTag(t *testing.T) {
+	tests := []struct {
+		name string
+		img  string
+		tag  string
+		want string
+	}{
+		{
+			name: "tag",
+			img:  "quay.io/argoproj/argocd",
+			tag:  "v2.10.5",
+			want: "quay.io/argoproj/argocd:v2.10.5",
+		},
+		{
+			name: "digest",
+			img:  "quay.io/argoproj/argocd",
+			tag:  "sha256:b835999eb5cf75d01a2678cd971095926d9c2566c9ffe746d04b83a6a0a2849f",
+			want: "quay.io/argoproj/argocd@sha256:b835999eb5cf75d01a2678cd971095926d9c2566c9ffe746d04b83a6a0a2849f",
+		},
+		{
+			name: "no tag",
+			img:  "quay.io/argoproj/argocd",
+			want: "quay.io/argoproj/argocd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CombineImageTag(tt.img, tt.tag); got != tt.want {
+				t.Errorf("CombineImageTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectExists(t *testing.T) {
+	cm := &corev1.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: "existing", Namespace: "argocd"}}
+	cl := fake.NewClientBuilder().WithObjects(cm).Build()
+
+	found, err := ObjectExists(cl, "argocd", "existing", &corev1.ConfigMap{})
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = ObjectExists(cl, "argocd", "missing", &corev1.ConfigMap{})
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	// errors other than NotFound are returned rather than treated as the object not existing
+	forbidden := apierrors.NewForbidden(corev1.Resource("configmaps"), "existing", errors.New("forbidden"))
+	cl = fake.NewClientBuilder().
+		WithObjects(cm).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return forbidden
+			},
+		}).
+		Build()
+
+	found, err = ObjectExists(cl, "argocd", "existing", &corev1.ConfigMap{})
+	assert.Equal(t, forbidden, err)
+	assert.False(t, found)
+
+	// IsObjectFound ignores them
+	assert.True(t, IsObjectFound(cl, "argocd", "existing", &corev1.ConfigMap{}))
+}
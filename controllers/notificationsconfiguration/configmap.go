@@ -3,9 +3,13 @@ package notificationsconfiguration
 import (
 	"context"
 	"fmt"
+	"os"
 	"reflect"
 
+	"gopkg.in/yaml.v2"
+
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -15,6 +19,9 @@ import (
 
 const (
 	ArgoCDNotificationsConfigMap = "argocd-notifications-cm"
+
+	// notificationsSubscriptionsKey is the argocd-notifications-cm key holding the global subscriptions
+	notificationsSubscriptionsKey = "subscriptions"
 )
 
 // reconcileNotificationsConfigmap will ensure that the notifications configuration is updated
@@ -60,6 +67,14 @@ func (r *NotificationsConfigurationReconciler) reconcileNotificationsConfigmap(c
 		expectedConfiguration[k] = v
 	}
 
+	subscriptions, err := getSubscriptions(cr.Spec.Subscriptions[notificationsSubscriptionsKey])
+	if err != nil {
+		return err
+	}
+	if subscriptions != "" {
+		expectedConfiguration[notificationsSubscriptionsKey] = subscriptions
+	}
+
 	if cr.Spec.Context != nil {
 		expectedConfiguration["context"] = mapToString(cr.Spec.Context)
 	}
@@ -82,3 +97,49 @@ func mapToString(m map[string]string) string {
 	}
 	return result
 }
+
+// getSubscriptions merges the default subscriptions from the ARGOCD_NOTIFICATIONS_DEFAULT_SUBSCRIPTIONS environment
+// variable with the given user subscriptions. Defaults come first and duplicate subscriptions are dropped. The user
+// subscriptions are returned unchanged when no defaults are configured.
+func getSubscriptions(userSubscriptions string) (string, error) {
+	defaultSubscriptions := os.Getenv(common.ArgoCDNotificationsDefaultSubscriptionsEnvName)
+	if defaultSubscriptions == "" {
+		return userSubscriptions, nil
+	}
+
+	defaults := []interface{}{}
+	if err := yaml.Unmarshal([]byte(defaultSubscriptions), &defaults); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", common.ArgoCDNotificationsDefaultSubscriptionsEnvName, err)
+	}
+
+	user := []interface{}{}
+	if err := yaml.Unmarshal([]byte(userSubscriptions), &user); err != nil {
+		return "", fmt.Errorf("failed to parse the notifications subscriptions: %w", err)
+	}
+
+	merged := []interface{}{}
+	for _, subscription := range append(defaults, user...) {
+		if !containsSubscription(merged, subscription) {
+			merged = append(merged, subscription)
+		}
+	}
+
+	if len(merged) == 0 {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func containsSubscription(subscriptions []interface{}, subscription interface{}) bool {
+	for _, s := range subscriptions {
+		if reflect.DeepEqual(s, subscription) {
+			return true
+		}
+	}
+	return false
+}
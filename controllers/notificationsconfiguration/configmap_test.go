@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 type notificationsOpts func(*v1alpha1.NotificationsConfiguration)
@@ -226,3 +228,55 @@ func TestReconcileNotifications_DeleteConfigMap(t *testing.T) {
 	assert.Equal(t, testCM.Data["trigger.on-sync-status-test"],
 		"- when: app.status.sync.status == 'Unknown' \n send: [my-custom-template]")
 }
+
+func TestReconcileNotifications_DefaultSubscriptions(t *testing.T) {
+	t.Setenv(common.ArgoCDNotificationsDefaultSubscriptionsEnvName, `- recipients:
+  - slack:alerts
+  triggers:
+  - on-sync-failed
+- recipients:
+  - email:ops@example.com
+  triggers:
+  - on-health-degraded
+`)
+
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.Subscriptions = map[string]string{
+			// the first subscription duplicates a default one
+			"subscriptions": `- recipients: [slack:alerts]
+  triggers: [on-sync-failed]
+- recipients: [slack:team]
+  triggers: [on-deployed]
+`,
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileNotificationsConfigmap(a))
+
+	testCM := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      ArgoCDNotificationsConfigMap,
+			Namespace: a.Namespace,
+		},
+		testCM))
+
+	subscriptions := []struct {
+		Recipients []string `yaml:"recipients"`
+		Triggers   []string `yaml:"triggers"`
+	}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(testCM.Data["subscriptions"]), &subscriptions))
+	assert.Len(t, subscriptions, 3)
+	assert.Equal(t, []string{"slack:alerts"}, subscriptions[0].Recipients)
+	assert.Equal(t, []string{"email:ops@example.com"}, subscriptions[1].Recipients)
+	assert.Equal(t, []string{"slack:team"}, subscriptions[2].Recipients)
+	assert.Equal(t, []string{"on-deployed"}, subscriptions[2].Triggers)
+}